@@ -0,0 +1,49 @@
+package islist
+
+// builder links nodes appended in ascending order to the end of a list, without searching it.
+// Appending is O(level) and finishing a list of n nodes is O(n).
+type builder[T, V any] struct {
+	sl   *SkipList[T, V]
+	last [MaxLevel]*Node[T, V] // Last node linked at each level.
+	pos  [MaxLevel]int         // Position of the last node at each level.
+}
+
+// newBuilder returns a builder that appends to the empty list.
+func newBuilder[T, V any](sl *SkipList[T, V]) *builder[T, V] {
+	b := &builder[T, V]{sl: sl}
+	for i := range b.last {
+		b.last[i] = sl.head
+	}
+	return b
+}
+
+// append links the node after the last appended node.
+// The node's key must be greater than the key of the last appended node.
+func (b *builder[T, V]) append(n *Node[T, V]) {
+	sl := b.sl
+	pos := sl.length + 1
+	for i := range n.levels {
+		b.last[i].levels[i].next = n
+		b.last[i].levels[i].span = pos - b.pos[i]
+		b.last[i] = n
+		b.pos[i] = pos
+	}
+	n.prev = sl.tail
+	sl.tail = n
+	sl.maxLevel = max(sl.maxLevel, len(n.levels))
+	sl.length++
+}
+
+// finish terminates each level and computes the max End of every forward pointer.
+func (b *builder[T, V]) finish() {
+	sl := b.sl
+	for i := 0; i < sl.maxLevel; i++ {
+		b.last[i].levels[i].next = nil
+		b.last[i].levels[i].span = sl.length - b.pos[i]
+	}
+	for i := 0; i < sl.maxLevel; i++ {
+		for n := sl.head; n != nil; n = n.levels[i].next {
+			n.updateMaxEnd(i, sl.compare)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package islist
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+)
+
+var (
+	ErrDuplicateInterval = errors.New("duplicate interval")
+	ErrUnsorted          = errors.New("intervals not sorted")
+)
+
+// NewFromSorted returns a new SkipList with intervals bounded by an ordered type, built from keys
+// sorted in ascending order. See NewFromSortedFunc.
+func NewFromSorted[T cmp.Ordered, V any](pool NodeAllocator[T, V], PCG *rand.PCG, keys []IntervalKey[T, V], opts ...Option) (*SkipList[T, V], error) {
+	sl, err := NewFromSortedFunc(pool, PCG, cmp.Compare[T], keys, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ordered(sl), nil
+}
+
+// NewFromSortedFunc returns a new SkipList that orders interval bounds using the compare function,
+// built from keys sorted in ascending order (by Start, then End).
+//
+// The list is built in a single O(n) pass without searching it. Returns an ErrUnsorted error if
+// the keys are out of order and an ErrDuplicateInterval error if an interval occurs more than once.
+func NewFromSortedFunc[T, V any](pool NodeAllocator[T, V], PCG *rand.PCG, compare func(a, b T) int, keys []IntervalKey[T, V], opts ...Option) (*SkipList[T, V], error) {
+	for i := 1; i < len(keys); i++ {
+		if keys[i].equalInterval(keys[i-1], compare) {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateInterval, keys[i])
+		}
+		if less(compare, keys[i], keys[i-1]) {
+			return nil, fmt.Errorf("%w: %s after %s", ErrUnsorted, keys[i], keys[i-1])
+		}
+	}
+	// Only take a head node from the pool once the keys are known to be valid.
+	sl := NewFunc(pool, PCG, compare, opts...)
+	sl.mergeSorted(keys)
+	return sl, nil
+}
+
+// BulkInsert adds the keys, in any order, to the list.
+//
+// The keys are sorted and merged with the existing nodes in a single O(n + m log m) pass, rather
+// than searching the list for each key. Returns an ErrDuplicateInterval error, and leaves the list
+// unchanged, if an interval occurs more than once in keys or already exists in the list.
+//
+// In coalescing mode, see WithCoalesce, the keys are inserted one at a time with Insert.
+func (sl *SkipList[T, V]) BulkInsert(keys []IntervalKey[T, V]) error {
+	if sl.merge != nil {
+		for _, k := range keys {
+			sl.Insert(k)
+		}
+		return nil
+	}
+	sorted := slices.Clone(keys)
+	slices.SortFunc(sorted, func(a, b IntervalKey[T, V]) int {
+		if c := sl.compare(a.Start, b.Start); c != IntervalEqual {
+			return c
+		}
+		return sl.compare(a.End, b.End)
+	})
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i].equalInterval(sorted[i-1], sl.compare) {
+			return fmt.Errorf("%w: %s", ErrDuplicateInterval, sorted[i])
+		}
+	}
+	// Check for existing intervals before the list is modified.
+	n, i := sl.head.levels[0].next, 0
+	for n != nil && i < len(sorted) {
+		switch {
+		case n.intervalKey.equalInterval(sorted[i], sl.compare):
+			return fmt.Errorf("%w: %s", ErrDuplicateInterval, sorted[i])
+		case less(sl.compare, n.intervalKey, sorted[i]):
+			n = n.levels[0].next
+		default:
+			i++
+		}
+	}
+	sl.mergeSorted(sorted)
+	sl.check()
+	return nil
+}
+
+// mergeSorted relinks the existing nodes of the list merged with new nodes for the sorted keys.
+// Existing nodes keep their levels. The keys must be unique and not exist in the list.
+// In coalescing mode, the keys are inserted one at a time with Insert instead.
+func (sl *SkipList[T, V]) mergeSorted(keys []IntervalKey[T, V]) {
+	if sl.merge != nil {
+		for _, k := range keys {
+			sl.Insert(k)
+		}
+		return
+	}
+	n := sl.head.levels[0].next
+	clear(sl.head.levels)
+	sl.maxLevel = 1
+	sl.length = 0
+	sl.tail = nil
+
+	b := newBuilder(sl)
+	i := 0
+	for n != nil || i < len(keys) {
+		if n != nil && (i == len(keys) || less(sl.compare, n.intervalKey, keys[i])) {
+			next := n.levels[0].next
+			b.append(n)
+			n = next
+			continue
+		}
+		b.append(newNode(sl.pool, sl.randomLevel(), keys[i]))
+		i++
+	}
+	b.finish()
+}
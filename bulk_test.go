@@ -0,0 +1,169 @@
+package islist
+
+import (
+	"errors"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// assertIndexable checks that every key in the list can be retrieved by its index position.
+func assertIndexable(t *testing.T, sl *SkipList[int, string]) {
+	t.Helper()
+	if err := sl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	i := 0
+	for ik := range sl.All() {
+		k, err := sl.GetByIndex(i)
+		if err != nil || !k.equalInterval(ik, sl.compare) {
+			t.Fatalf("expected %s at index %d. got %v, %v", ik, i, k, err)
+		}
+		i++
+	}
+	if i != sl.length {
+		t.Fatalf("list length mismatch. got %d, expected %d", i, sl.length)
+	}
+}
+
+func TestNodeAllocators(t *testing.T) {
+	tests := []struct {
+		name string
+		pool NodeAllocator[int, string]
+	}{
+		{name: "Pool", pool: NewNodePool[int, string]()},
+		{name: "Arena", pool: NewNodeArena[int, string](16)},
+		{name: "Compact", pool: NewCompactNodePool[int, string]()},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// A probability of 1/2 gives nodes both inline and overflow levels in a CompactNodePool.
+			list := New(test.pool, rand.NewPCG(2, 3), WithProbability(0.5), WithDebugHook(func(err error) {
+				t.Fatalf("unexpected invariant violation: %v", err)
+			}))
+			r := rand.New(rand.NewPCG(4, 5))
+			for i := 0; i < 2000; i++ {
+				start := r.IntN(1000)
+				ik := NewIntervalKey(start, start+r.IntN(50), "key")
+				switch r.IntN(4) {
+				case 0, 1:
+					list.Insert(ik)
+				case 2:
+					list.Delete(ik)
+				case 3:
+					list.DeleteOverlapping(NewIntervalQuery[int, string](start, start+5))
+				}
+			}
+			assertIndexable(t, list)
+		})
+	}
+}
+
+func TestNewFromSorted(t *testing.T) {
+	t.Run("Build list from sorted keys", func(t *testing.T) {
+		keys := []IntervalKey[int, string]{
+			NewIntervalKey(0, 1000, "outer"),
+			NewIntervalKey(5, 9, "test-1"),
+			NewIntervalKey(10, 20, "test-2"),
+			NewIntervalKey(10, 25, "test-3"),
+			NewIntervalKey(30, 40, "test-4"),
+		}
+		list, err := NewFromSorted(NewNodePool[int, string](), rand.NewPCG(2, 3), keys)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got := slices.Collect(list.All()); !slices.Equal(got, keys) {
+			t.Errorf("expected %v. got %v", keys, got)
+		}
+		reversed := slices.Clone(keys)
+		slices.Reverse(reversed)
+		if got := slices.Collect(list.Backward()); !slices.Equal(got, reversed) {
+			t.Errorf("expected %v. got %v", reversed, got)
+		}
+		assertIndexable(t, list)
+		r := list.Overlaps(NewIntervalQuery[int, string](500, 510), QueryParam{})
+		if len(r) != 1 || r[0].Key != "outer" {
+			t.Errorf("expected [outer]. got %v", r)
+		}
+	})
+
+	t.Run("Unsorted keys", func(t *testing.T) {
+		keys := []IntervalKey[int, string]{NewIntervalKey(10, 20, "a"), NewIntervalKey(5, 9, "b")}
+		arena := NewNodeArena[int, string](0)
+		_, err := NewFromSorted(arena, rand.NewPCG(2, 3), keys)
+		if !errors.Is(err, ErrUnsorted) {
+			t.Errorf("expected %v. got %v", ErrUnsorted, err)
+		}
+		if arena.nodes != nil {
+			t.Errorf("expected no node taken from the allocator")
+		}
+	})
+
+	t.Run("Duplicate keys", func(t *testing.T) {
+		keys := []IntervalKey[int, string]{NewIntervalKey(5, 9, "a"), NewIntervalKey(5, 9, "b")}
+		_, err := NewFromSorted(NewNodePool[int, string](), rand.NewPCG(2, 3), keys)
+		if !errors.Is(err, ErrDuplicateInterval) {
+			t.Errorf("expected %v. got %v", ErrDuplicateInterval, err)
+		}
+	})
+}
+
+func TestBulkInsert(t *testing.T) {
+	t.Run("Bulk insert matches individual inserts", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(1, 2))
+		var first, second []IntervalKey[int, string]
+		for _, i := range r.Perm(1000) {
+			ik := NewIntervalKey(i*10, i*10+r.IntN(50), "key")
+			if i%3 == 0 {
+				first = append(first, ik)
+			} else {
+				second = append(second, ik)
+			}
+		}
+		expected := newTestList()
+		for _, ik := range append(slices.Clone(first), second...) {
+			expected.Insert(ik)
+		}
+		list := newTestList()
+		for _, ik := range first {
+			list.Insert(ik)
+		}
+		if err := list.BulkInsert(second); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !slices.Equal(slices.Collect(list.All()), slices.Collect(expected.All())) {
+			t.Errorf("keys mismatch after bulk insert")
+		}
+		if !slices.Equal(slices.Collect(list.Backward()), slices.Collect(expected.Backward())) {
+			t.Errorf("backward keys mismatch after bulk insert")
+		}
+		assertIndexable(t, list)
+		for i := 0; i < 100; i++ {
+			q := NewIntervalQuery[int, string](i*100, i*100+75)
+			if got, want := len(list.Overlaps(q, QueryParam{})), len(expected.Overlaps(q, QueryParam{})); got != want {
+				t.Errorf("query %s: expected %d overlapping intervals. got %d", q, want, got)
+			}
+		}
+	})
+
+	t.Run("Duplicate of existing interval leaves list unchanged", func(t *testing.T) {
+		list := newIterTestList()
+		err := list.BulkInsert([]IntervalKey[int, string]{NewIntervalKey(1, 2, "new"), NewIntervalKey(30, 40, "dup")})
+		if !errors.Is(err, ErrDuplicateInterval) {
+			t.Errorf("expected %v. got %v", ErrDuplicateInterval, err)
+		}
+		assertListEqual(t, list, expectedList{level: list.maxLevel, length: 5})
+		if list.Get(NewIntervalQuery[int, string](1, 2)) != nil {
+			t.Errorf("expected list to be unchanged")
+		}
+	})
+
+	t.Run("Duplicate in input", func(t *testing.T) {
+		list := newTestList()
+		err := list.BulkInsert([]IntervalKey[int, string]{NewIntervalKey(5, 9, "a"), NewIntervalKey(1, 2, "b"), NewIntervalKey(5, 9, "c")})
+		if !errors.Is(err, ErrDuplicateInterval) {
+			t.Errorf("expected %v. got %v", ErrDuplicateInterval, err)
+		}
+		assertListEqual(t, list, expectedList{level: 1, length: 0})
+	})
+}
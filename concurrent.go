@@ -0,0 +1,357 @@
+package islist
+
+import (
+	"cmp"
+	"io"
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"sync"
+)
+
+// ConcurrentSkipList is a SkipList that is safe for concurrent use by multiple goroutines.
+//
+// Writes are serialized by a RWMutex while reads share the read lock, so queries run in parallel
+// with each other. Keys returned by the list are copies, as the underlying nodes may be updated or
+// reused by writes once the lock is released.
+//
+// Iterators, and OverlapsBatch, collect the keys under the read lock and yield them once it is
+// released, so the loop body may call any method of the list. A reader that takes the read lock
+// again while holding it would deadlock with a waiting writer.
+type ConcurrentSkipList[T, V any] struct {
+	mu sync.RWMutex
+	sl *SkipList[T, V]
+}
+
+// NewConcurrent returns a new instance of a ConcurrentSkipList with intervals bounded by an ordered type.
+func NewConcurrent[T cmp.Ordered, V any](pool NodeAllocator[T, V], PCG *rand.PCG, opts ...Option) *ConcurrentSkipList[T, V] {
+	return &ConcurrentSkipList[T, V]{sl: New(pool, PCG, opts...)}
+}
+
+// NewConcurrentFunc returns a new instance of a ConcurrentSkipList that orders interval bounds using
+// the compare function. See NewFunc.
+func NewConcurrentFunc[T, V any](pool NodeAllocator[T, V], PCG *rand.PCG, compare func(a, b T) int, opts ...Option) *ConcurrentSkipList[T, V] {
+	return &ConcurrentSkipList[T, V]{sl: NewFunc(pool, PCG, compare, opts...)}
+}
+
+// Insert adds a new key to the list.
+// If the key already exist, it updates the existing key and returns the previous key.
+func (c *ConcurrentSkipList[T, V]) Insert(intervalKey IntervalKey[T, V]) *IntervalKey[T, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sl.Insert(intervalKey)
+}
+
+// InsertKey adds a new key to the list without allocating, see SkipList.InsertKey.
+func (c *ConcurrentSkipList[T, V]) InsertKey(intervalKey IntervalKey[T, V]) (IntervalKey[T, V], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sl.InsertKey(intervalKey)
+}
+
+// TryInsert validates the key's interval before adding it to the list, see SkipList.TryInsert.
+func (c *ConcurrentSkipList[T, V]) TryInsert(intervalKey IntervalKey[T, V]) (*IntervalKey[T, V], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sl.TryInsert(intervalKey)
+}
+
+// InsertExclusive adds a new key to the list if its interval doesn't overlap any existing interval.
+// See SkipList.InsertExclusive.
+func (c *ConcurrentSkipList[T, V]) InsertExclusive(intervalKey IntervalKey[T, V]) ([]IntervalKey[T, V], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sl.InsertExclusive(intervalKey)
+}
+
+// Delete removes a key with the specified interval.
+// Returns the key of the deleted node if found.
+func (c *ConcurrentSkipList[T, V]) Delete(interval IntervalKey[T, V]) *IntervalKey[T, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sl.Delete(interval)
+}
+
+// DeleteKey removes a key with the specified interval without allocating, see SkipList.DeleteKey.
+func (c *ConcurrentSkipList[T, V]) DeleteKey(interval IntervalKey[T, V]) (IntervalKey[T, V], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sl.DeleteKey(interval)
+}
+
+// Subtract removes the range of the interval from the list, trimming or splitting the intervals
+// that overlap it. See SkipList.Subtract.
+func (c *ConcurrentSkipList[T, V]) Subtract(interval IntervalKey[T, V]) []IntervalKey[T, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sl.Subtract(interval)
+}
+
+// DeleteOverlapping removes all keys that overlap the query interval.
+// Returns the deleted keys in list order.
+func (c *ConcurrentSkipList[T, V]) DeleteOverlapping(interval IntervalKey[T, V]) []IntervalKey[T, V] {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sl.DeleteOverlapping(interval)
+}
+
+// DeleteRange removes the keys at the 0-based index positions in [startIndex, endIndex).
+// Returns the deleted keys in list order.
+func (c *ConcurrentSkipList[T, V]) DeleteRange(startIndex, endIndex int) ([]IntervalKey[T, V], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sl.DeleteRange(startIndex, endIndex)
+}
+
+// Overlaps returns a copy of all keys that overlap the query interval, in list order.
+func (c *ConcurrentSkipList[T, V]) Overlaps(interval IntervalKey[T, V], qParam QueryParam) []*IntervalKey[T, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return copyKeys(c.sl.Overlaps(interval, qParam))
+}
+
+// OverlapsPage returns a copy of up to limit keys that overlap the query interval, starting after
+// the position of the token, and a token for the next page, see SkipList.OverlapsPage.
+func (c *ConcurrentSkipList[T, V]) OverlapsPage(interval IntervalKey[T, V], limit int, token PageToken[T]) ([]*IntervalKey[T, V], PageToken[T]) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	keys, next := c.sl.OverlapsPage(interval, limit, token)
+	return copyKeys(keys), next
+}
+
+// OverlapsBatch calls fn with each key that overlaps each of the query intervals, in a single
+// sweep of the list, see SkipList.OverlapsBatch.
+func (c *ConcurrentSkipList[T, V]) OverlapsBatch(queries []IntervalKey[T, V], fn func(qi int, ik IntervalKey[T, V])) {
+	type overlap struct {
+		qi int
+		ik IntervalKey[T, V]
+	}
+	var overlaps []overlap
+	c.mu.RLock()
+	c.sl.OverlapsBatch(queries, func(qi int, ik IntervalKey[T, V]) {
+		overlaps = append(overlaps, overlap{qi, ik})
+	})
+	c.mu.RUnlock()
+	for _, o := range overlaps {
+		fn(o.qi, o.ik)
+	}
+}
+
+// StabFirst returns the first key, in list order, whose interval contains the point.
+func (c *ConcurrentSkipList[T, V]) StabFirst(point T) (IntervalKey[T, V], bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sl.StabFirst(point)
+}
+
+// Covers reports whether any interval in the list contains the point.
+func (c *ConcurrentSkipList[T, V]) Covers(point T) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sl.Covers(point)
+}
+
+// Get retrieves a copy of a key by its interval.
+// Returns nil if the interval doesn't exist.
+func (c *ConcurrentSkipList[T, V]) Get(interval IntervalKey[T, V]) *IntervalKey[T, V] {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return copyKey(c.sl.Get(interval))
+}
+
+// Floor returns the last key whose interval starts at or before the point.
+func (c *ConcurrentSkipList[T, V]) Floor(point T) (IntervalKey[T, V], bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sl.Floor(point)
+}
+
+// Ceiling returns the first key whose interval starts at or after the point.
+func (c *ConcurrentSkipList[T, V]) Ceiling(point T) (IntervalKey[T, V], bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sl.Ceiling(point)
+}
+
+// Prev returns the key that precedes the interval in the list.
+func (c *ConcurrentSkipList[T, V]) Prev(interval IntervalKey[T, V]) (IntervalKey[T, V], bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sl.Prev(interval)
+}
+
+// Next returns the key that succeeds the interval in the list.
+func (c *ConcurrentSkipList[T, V]) Next(interval IntervalKey[T, V]) (IntervalKey[T, V], bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sl.Next(interval)
+}
+
+// Rank returns the 0-based index position of the interval in the list.
+// Returns false if the interval doesn't exist.
+func (c *ConcurrentSkipList[T, V]) Rank(interval IntervalKey[T, V]) (int, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sl.Rank(interval)
+}
+
+// RankOf returns the number of keys whose interval starts before the point.
+func (c *ConcurrentSkipList[T, V]) RankOf(point T) int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sl.RankOf(point)
+}
+
+// GetByIndex retrieves a copy of a key by its index position in the list.
+func (c *ConcurrentSkipList[T, V]) GetByIndex(index int) (*IntervalKey[T, V], error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	k, err := c.sl.GetByIndex(index)
+	return copyKey(k), err
+}
+
+// Print outputs a visual representation of the list from the given startLevel to the base level.
+func (c *ConcurrentSkipList[T, V]) Print(w io.Writer, startLevel int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.sl.Print(w, startLevel)
+}
+
+// Validate checks the structural invariants of the list, see SkipList.Validate.
+func (c *ConcurrentSkipList[T, V]) Validate() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sl.Validate()
+}
+
+// copyKey returns a pointer to a copy of the key, or nil.
+func copyKey[T, V any](k *IntervalKey[T, V]) *IntervalKey[T, V] {
+	if k == nil {
+		return nil
+	}
+	c := *k
+	return &c
+}
+
+// copyKeys returns pointers to copies of the keys, backed by a single allocation.
+func copyKeys[T, V any](keys []*IntervalKey[T, V]) []*IntervalKey[T, V] {
+	if keys == nil {
+		return nil
+	}
+	values := make([]IntervalKey[T, V], len(keys))
+	for i, k := range keys {
+		values[i] = *k
+		keys[i] = &values[i]
+	}
+	return keys
+}
+
+// All returns an iterator over all keys in the list, in ascending order.
+func (c *ConcurrentSkipList[T, V]) All() iter.Seq[IntervalKey[T, V]] {
+	return rLockSeq(&c.mu, c.sl.All())
+}
+
+// Backward returns an iterator over all keys in the list, in descending order.
+func (c *ConcurrentSkipList[T, V]) Backward() iter.Seq[IntervalKey[T, V]] {
+	return rLockSeq(&c.mu, c.sl.Backward())
+}
+
+// BackwardFrom returns an iterator over the keys whose interval starts at or before the point,
+// in descending order.
+func (c *ConcurrentSkipList[T, V]) BackwardFrom(point T) iter.Seq[IntervalKey[T, V]] {
+	return rLockSeq(&c.mu, c.sl.BackwardFrom(point))
+}
+
+// OverlapsSeq returns an iterator over the keys that overlap the query interval, in list order.
+func (c *ConcurrentSkipList[T, V]) OverlapsSeq(interval IntervalKey[T, V]) iter.Seq2[int, IntervalKey[T, V]] {
+	seq := c.sl.OverlapsSeq(interval)
+	return func(yield func(int, IntervalKey[T, V]) bool) {
+		var indexes []int
+		var keys []IntervalKey[T, V]
+		c.mu.RLock()
+		for i, k := range seq {
+			indexes, keys = append(indexes, i), append(keys, k)
+		}
+		c.mu.RUnlock()
+		for i, k := range keys {
+			if !yield(indexes[i], k) {
+				return
+			}
+		}
+	}
+}
+
+// Stab returns an iterator over the keys whose interval contains the point, in list order.
+func (c *ConcurrentSkipList[T, V]) Stab(point T) iter.Seq[IntervalKey[T, V]] {
+	return rLockSeq(&c.mu, c.sl.Stab(point))
+}
+
+// Gaps returns an iterator over the sub-ranges of the query interval that are not covered by any
+// interval in the list, in ascending order.
+func (c *ConcurrentSkipList[T, V]) Gaps(interval IntervalKey[T, V]) iter.Seq[IntervalKey[T, V]] {
+	return rLockSeq(&c.mu, c.sl.Gaps(interval))
+}
+
+// FirstFitConcurrent returns the earliest free slot of length minLen that starts at or after the
+// point after, see FirstFit. The slot may be taken by another goroutine once the read lock is
+// released, so callers that book it should use InsertExclusive.
+func FirstFitConcurrent[T Number, V any](c *ConcurrentSkipList[T, V], minLen, after T) (IntervalKey[T, V], bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return FirstFit(c.sl, minLen, after)
+}
+
+// Range returns an iterator over the keys whose Start is within [from, to), in ascending order.
+func (c *ConcurrentSkipList[T, V]) Range(from, to T) iter.Seq[IntervalKey[T, V]] {
+	return rLockSeq(&c.mu, c.sl.Range(from, to))
+}
+
+// rLockSeq wraps the iterator to collect its elements under the read lock, and yield them once
+// the lock is released.
+func rLockSeq[E any](mu *sync.RWMutex, seq iter.Seq[E]) iter.Seq[E] {
+	return func(yield func(E) bool) {
+		mu.RLock()
+		s := slices.Collect(seq)
+		mu.RUnlock()
+		for _, e := range s {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// WriteTo writes the list to w, see SkipList.WriteTo.
+func (c *ConcurrentSkipList[T, V]) WriteTo(w io.Writer) (int64, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sl.WriteTo(w)
+}
+
+// ReadFrom replaces the contents of the list with a list read from r, see SkipList.ReadFrom.
+func (c *ConcurrentSkipList[T, V]) ReadFrom(r io.Reader) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sl.ReadFrom(r)
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface.
+func (c *ConcurrentSkipList[T, V]) MarshalBinary() ([]byte, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.sl.MarshalBinary()
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface.
+func (c *ConcurrentSkipList[T, V]) UnmarshalBinary(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sl.UnmarshalBinary(data)
+}
+
+// BulkInsert adds the keys, in any order, to the list, see SkipList.BulkInsert.
+func (c *ConcurrentSkipList[T, V]) BulkInsert(keys []IntervalKey[T, V]) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sl.BulkInsert(keys)
+}
@@ -0,0 +1,150 @@
+package islist
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestConcurrentList() *ConcurrentSkipList[int, string] {
+	return NewConcurrent(NewNodePool[int, string](), rand.NewPCG(2, 3))
+}
+
+func TestConcurrentInsertAndOverlaps(t *testing.T) {
+	const writers, readers, perWriter = 4, 4, 500
+	list := newTestConcurrentList()
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				start := (w*perWriter + i) * 10
+				list.Insert(NewIntervalKey(start, start+5, "key"))
+			}
+		}(w)
+	}
+	for r := 0; r < readers; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				for _, k := range list.Overlaps(NewIntervalQuery[int, string](i*10, i*10+100), QueryParam{}) {
+					if k.Key != "key" {
+						t.Errorf("unexpected key %s", k)
+					}
+				}
+				_ = list.Get(NewIntervalQuery[int, string](i*10, i*10+5))
+			}
+		}()
+	}
+	wg.Wait()
+
+	r := list.Overlaps(NewIntervalQuery[int, string](0, writers*perWriter*10), QueryParam{})
+	if len(r) != writers*perWriter {
+		t.Errorf("expected %d intervals. got %d", writers*perWriter, len(r))
+	}
+}
+
+func TestConcurrentDelete(t *testing.T) {
+	const n = 1000
+	list := newTestConcurrentList()
+	for i := 0; i < n; i++ {
+		list.Insert(NewIntervalKey(i*10, i*10+5, "key"))
+	}
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := w; i < n; i += 4 {
+				if k := list.Delete(NewIntervalQuery[int, string](i*10, i*10+5)); k == nil {
+					t.Errorf("expected key for [%d,%d]", i*10, i*10+5)
+				}
+				_, _ = list.GetByIndex(0)
+			}
+		}(w)
+	}
+	wg.Wait()
+	if _, err := list.GetByIndex(0); err == nil {
+		t.Errorf("expected empty list after concurrent deletes")
+	}
+}
+
+func TestConcurrentReturnsCopies(t *testing.T) {
+	list := newTestConcurrentList()
+	list.Insert(NewIntervalKey(0, 10, "original"))
+	k := list.Get(NewIntervalQuery[int, string](0, 10))
+	list.Insert(NewIntervalKey(0, 10, "updated"))
+	if k.Key != "original" {
+		t.Errorf("expected returned key to be unaffected by updates. got %s", k.Key)
+	}
+}
+
+func TestConcurrentIterators(t *testing.T) {
+	list := newTestConcurrentList()
+	for i := 0; i < 10; i++ {
+		list.Insert(NewIntervalKey(i*10, i*10+5, "key"))
+	}
+	// The loop bodies call methods of the list, which must not deadlock with a waiting writer.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for k := range list.All() {
+			list.Insert(NewIntervalKey(k.Start+1, k.End, "inner"))
+			list.Get(k)
+		}
+		for _, k := range list.OverlapsSeq(NewIntervalQuery[int, string](0, 50)) {
+			list.Delete(k)
+		}
+		list.OverlapsBatch([]IntervalKey[int, string]{NewIntervalQuery[int, string](0, 100)}, func(_ int, k IntervalKey[int, string]) {
+			list.Covers(k.Start)
+		})
+	}()
+	for i := 0; i < 100; i++ {
+		list.Insert(NewIntervalKey(1000+i, 1001+i, "writer"))
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected iteration to finish")
+	}
+	if err := list.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFirstFitConcurrent(t *testing.T) {
+	list := newTestConcurrentList()
+	list.Insert(NewIntervalKey(0, 10, "a"))
+	list.Insert(NewIntervalKey(20, 30, "b"))
+	var wg sync.WaitGroup
+	var booked atomic.Int32
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				slot, ok := FirstFitConcurrent(list, 5, 0)
+				if !ok {
+					t.Error("expected a slot")
+					return
+				}
+				if _, err := list.InsertExclusive(slot); err == nil {
+					booked.Add(1)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if n := len(slices.Collect(list.All())); booked.Load() != 8 || n != 10 {
+		t.Errorf("expected 8 booked slots in 10 keys. got %d in %d", booked.Load(), n)
+	}
+	if err := list.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,170 @@
+package islist
+
+// Cursor represents a position in a list that remembers its search path, so seeking near the
+// position runs in O(log d), where d is the distance from the position, rather than O(log n).
+// Moving to the next key is O(1). Inserting and deleting at the cursor skip the search, but still
+// update the levels of the whole path in O(log n).
+//
+// A cursor is at a key of the list, or past its last key. It stays valid across modifications
+// made through it. After any other modification of the list, the cursor searches its position
+// again from the head on its next use.
+// A cursor is not safe for concurrent use.
+type Cursor[T, V any] struct {
+	sl     *SkipList[T, V]
+	path   [MaxLevel]*Node[T, V] // Last node before the position at each level.
+	pos    [MaxLevel]int         // Positions of the path nodes (the head is at position 0).
+	mods   uint64                // Number of modifications of the list the path is valid for.
+	target IntervalKey[T, V]     // The position is the first key not less than the target,
+	after  bool                  // or greater than the target if after,
+	seeked bool                  // or the first key of the list if not seeked.
+}
+
+// Cursor returns a new cursor at the first key of the list.
+func (sl *SkipList[T, V]) Cursor() *Cursor[T, V] {
+	c := &Cursor[T, V]{sl: sl}
+	c.reset()
+	return c
+}
+
+// Key returns the key at the cursor, or false if the cursor is past the last key.
+func (c *Cursor[T, V]) Key() (IntervalKey[T, V], bool) {
+	c.sync()
+	return c.sl.keyOf(c.path[0].levels[0].next)
+}
+
+// Index returns the 0-based index position of the cursor in the list, see GetByIndex.
+// This is the length of the list if the cursor is past the last key.
+func (c *Cursor[T, V]) Index() int {
+	c.sync()
+	return c.pos[0]
+}
+
+// Seek moves the cursor to the first key that is not less than the interval, ordered by Start
+// then End, and returns it. Returns false if there is no such key. Seeking to
+// NewIntervalQuery(point, point) moves to the first key that starts at or after the point.
+func (c *Cursor[T, V]) Seek(interval IntervalKey[T, V]) (IntervalKey[T, V], bool) {
+	if c.mods != c.sl.mods {
+		c.reset()
+	}
+	c.target, c.after, c.seeked = interval, false, true
+	c.seek()
+	return c.Key()
+}
+
+// Next moves the cursor to the next key and returns it.
+// Returns false if the cursor is past the last key.
+func (c *Cursor[T, V]) Next() (IntervalKey[T, V], bool) {
+	c.sync()
+	n := c.path[0].levels[0].next
+	if n == nil {
+		return IntervalKey[T, V]{}, false
+	}
+	p := c.pos[0] + 1
+	for i := range n.levels {
+		c.path[i], c.pos[i] = n, p
+	}
+	c.target, c.after, c.seeked = n.intervalKey, true, true
+	return c.Key()
+}
+
+// Insert adds a new key to the list near the cursor, see SkipList.Insert, and moves the cursor
+// to it. Returns the previous key if the key's interval already exists.
+func (c *Cursor[T, V]) Insert(intervalKey IntervalKey[T, V]) *IntervalKey[T, V] {
+	if c.sl.merge != nil {
+		// The merged interval isn't known before coalescing, so search it again.
+		ik, k, ok := c.sl.coalesce(intervalKey)
+		c.sl.check()
+		c.Seek(ik)
+		if !ok {
+			return nil
+		}
+		return &k
+	}
+	c.Seek(intervalKey)
+	k, ok := c.sl.insertAt(&c.path, &c.pos, intervalKey)
+	c.sl.check()
+	c.mods = c.sl.mods
+	if !ok {
+		return nil
+	}
+	prev := k
+	return &prev
+}
+
+// Delete removes the key at the cursor, moves the cursor to the next key, and returns the deleted
+// key. Returns false if the cursor is past the last key.
+func (c *Cursor[T, V]) Delete() (IntervalKey[T, V], bool) {
+	c.sync()
+	n := c.path[0].levels[0].next
+	if n == nil {
+		return IntervalKey[T, V]{}, false
+	}
+	c.target, c.after, c.seeked = n.intervalKey, true, true
+	k := c.sl.deleteAt(&c.path, n)
+	c.sl.check()
+	c.mods = c.sl.mods
+	return k, true
+}
+
+// reset moves the path to the head of the list.
+func (c *Cursor[T, V]) reset() {
+	for i := range c.path {
+		c.path[i], c.pos[i] = c.sl.head, 0
+	}
+	c.mods = c.sl.mods
+}
+
+// sync searches the position of the cursor again if the list was modified other than through it.
+func (c *Cursor[T, V]) sync() {
+	if c.mods == c.sl.mods {
+		return
+	}
+	c.reset()
+	if c.seeked {
+		c.seek()
+	}
+}
+
+// before reports whether the key is before the position of the cursor.
+func (c *Cursor[T, V]) before(ik IntervalKey[T, V]) bool {
+	if c.after {
+		return !less(c.sl.compare, c.target, ik)
+	}
+	return less(c.sl.compare, ik, c.target)
+}
+
+// brackets reports whether the position of the cursor is between the path node at the level and
+// its next node at the level.
+func (c *Cursor[T, V]) brackets(level int) bool {
+	n := c.path[level]
+	if n != c.sl.head && !c.before(n.intervalKey) {
+		return false
+	}
+	next := n.levels[level].next
+	return next == nil || !c.before(next.intervalKey)
+}
+
+// seek moves the path to the position of the cursor.
+//
+// If the path brackets the position at a level, it does at every level above, since the path nodes
+// of higher levels are nodes of lower levels. So the search climbs from the base level to the
+// first level that brackets the position, about log(d) levels for a distance d, and descends
+// from there.
+func (c *Cursor[T, V]) seek() {
+	sl := c.sl
+	i := 0
+	for i < sl.maxLevel-1 && !c.brackets(i) {
+		i++
+	}
+	n, p := c.path[i], c.pos[i]
+	if n != sl.head && !c.before(n.intervalKey) {
+		n, p = sl.head, 0 // The position is before the top level path node.
+	}
+	for ; i >= 0; i-- {
+		for n.levels[i].next != nil && c.before(n.levels[i].next.intervalKey) {
+			p += n.levels[i].span
+			n = n.levels[i].next
+		}
+		c.path[i], c.pos[i] = n, p
+	}
+}
@@ -0,0 +1,156 @@
+package islist
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	newList := func() *SkipList[int, string] {
+		list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithDebugHook(func(err error) {
+			t.Fatalf("unexpected invariant violation: %v", err)
+		}))
+		for i := 0; i < 500; i++ {
+			list.Insert(NewIntervalKey(i*10, i*10+5, "key"))
+		}
+		return list
+	}
+	compareKeys := func(a, b IntervalKey[int, string]) int {
+		return cmp.Or(cmp.Compare(a.Start, b.Start), cmp.Compare(a.End, b.End))
+	}
+
+	t.Run("Next", func(t *testing.T) {
+		list := newList()
+		var got []IntervalKey[int, string]
+		c := list.Cursor()
+		for k, ok := c.Key(); ok; k, ok = c.Next() {
+			if i := c.Index(); i != len(got) {
+				t.Fatalf("expected index %d. got %d", len(got), i)
+			}
+			got = append(got, k)
+		}
+		if expected := slices.Collect(list.All()); !slices.Equal(got, expected) {
+			t.Errorf("expected %d keys in list order. got %d", len(expected), len(got))
+		}
+		if c.Index() != list.length {
+			t.Errorf("expected index %d past the last key. got %d", list.length, c.Index())
+		}
+	})
+
+	t.Run("Seek", func(t *testing.T) {
+		list := newList()
+		keys := slices.Collect(list.All())
+		c := list.Cursor()
+		r := rand.New(rand.NewPCG(1, 2))
+		for i := 0; i < 1000; i++ {
+			start := r.IntN(5100)
+			q := NewIntervalQuery[int, string](start, start+r.IntN(10))
+			k, ok := c.Seek(q)
+			j, _ := slices.BinarySearchFunc(keys, q, compareKeys)
+			if ok != (j < len(keys)) || ok && k != keys[j] || c.Index() != j {
+				t.Fatalf("seek %s: expected index %d. got %v at index %d", q, j, k, c.Index())
+			}
+		}
+	})
+
+	t.Run("Insert and delete", func(t *testing.T) {
+		list := newList()
+		keys := slices.Collect(list.All())
+		c := list.Cursor()
+		r := rand.New(rand.NewPCG(3, 4))
+		for i := 0; i < 2000; i++ {
+			start := r.IntN(5100)
+			ik := NewIntervalKey(start, start+r.IntN(10), "key")
+			switch r.IntN(3) {
+			case 0:
+				c.Insert(ik)
+				if j, found := slices.BinarySearchFunc(keys, ik, compareKeys); !found {
+					keys = slices.Insert(keys, j, ik)
+				}
+				if k, _ := c.Key(); k != ik {
+					t.Fatalf("expected cursor at inserted key %s. got %s", ik, k)
+				}
+			case 1:
+				j := c.Index()
+				k, ok := c.Delete()
+				if ok != (j < len(keys)) || ok && k != keys[j] {
+					t.Fatalf("expected to delete index %d. got %v", j, k)
+				}
+				if ok {
+					keys = slices.Delete(keys, j, j+1)
+				}
+			case 2:
+				c.Seek(ik)
+			}
+		}
+		if got := slices.Collect(list.All()); !slices.Equal(got, keys) {
+			t.Errorf("expected %d keys. got %d", len(keys), len(got))
+		}
+	})
+
+	t.Run("Insert coalesced", func(t *testing.T) {
+		list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithCoalesce(func(a, b string) string { return a + b }))
+		list.Insert(NewIntervalKey(0, 10, "a"))
+		list.Insert(NewIntervalKey(30, 40, "b"))
+		c := list.Cursor()
+		if prev := c.Insert(NewIntervalKey(5, 15, "c")); prev == nil || prev.Key != "a" {
+			t.Errorf("expected previous key a. got %v", prev)
+		}
+		if k, _ := c.Key(); k != NewIntervalKey(0, 15, "ac") || c.Index() != 0 {
+			t.Errorf("expected cursor at [0,15] ac at index 0. got %s at %d", k, c.Index())
+		}
+		if prev := c.Insert(NewIntervalKey(20, 25, "d")); prev != nil {
+			t.Errorf("expected no previous key. got %s", prev)
+		}
+		if k, _ := c.Key(); k != NewIntervalKey(20, 25, "d") || c.Index() != 1 {
+			t.Errorf("expected cursor at [20,25] d at index 1. got %s at %d", k, c.Index())
+		}
+	})
+
+	t.Run("Modified list", func(t *testing.T) {
+		list := newList()
+		c := list.Cursor()
+		c.Seek(NewIntervalQuery[int, string](100, 105))
+		list.Delete(NewIntervalQuery[int, string](100, 105))
+		list.Insert(NewIntervalKey(0, 1, "key"))
+		if k, _ := c.Key(); k.Start != 110 || c.Index() != 11 {
+			t.Errorf("expected [110,115] at index 11. got %s at %d", k, c.Index())
+		}
+		list.Delete(NewIntervalQuery[int, string](110, 115))
+		if k, _ := c.Next(); k.Start != 130 {
+			t.Errorf("expected [130,135]. got %s", k)
+		}
+	})
+}
+
+func TestOverlapsPage(t *testing.T) {
+	list := newTestList()
+	for i := 0; i < 100; i++ {
+		list.Insert(NewIntervalKey(i*10, i*10+15, "key"))
+	}
+	list.Insert(NewIntervalKey(0, 1000, "long"))
+	q := NewIntervalQuery[int, string](200, 500)
+	expected := list.Overlaps(q, QueryParam{})
+
+	var got []*IntervalKey[int, string]
+	var token PageToken[int]
+	for pages := 1; ; pages++ {
+		keys, next := list.OverlapsPage(q, 7, token)
+		if len(keys) > 7 {
+			t.Fatalf("expected at most 7 keys per page. got %d", len(keys))
+		}
+		got = append(got, keys...)
+		if !next.Valid {
+			if want := (len(expected) + 6) / 7; pages != want {
+				t.Errorf("expected %d pages. got %d", want, pages)
+			}
+			break
+		}
+		token = next
+	}
+	if !slices.EqualFunc(got, expected, func(a, b *IntervalKey[int, string]) bool { return *a == *b }) {
+		t.Errorf("expected pages to match Overlaps. got %v, expected %v", got, expected)
+	}
+}
@@ -0,0 +1,192 @@
+package islist
+
+import (
+	"iter"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+// Number is a constraint for interval bounds that support arithmetic.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Gaps returns an iterator over the sub-ranges of the query interval that are not covered by any
+// interval in the list, in ascending order. Each gap is bounded by the End and Start of the
+// neighbouring intervals, or by the query bounds, and has a zero value Key.
+//
+// Gaps follow the list Bounds, like the query interval, so a gap doesn't overlap its neighbours
+// and InsertExclusive accepts it. In Closed lists of numbers, created with New or NewFromSorted,
+// a gap starts and ends at the values next to the bounds of its neighbours, e.g. [11,19] between
+// [0,10] and [20,30]. Other bound types have no next value, so in Closed lists of those a gap
+// shares the bounds of its neighbours. In Open lists a gap shares the bounds of its neighbours,
+// as an open gap that includes them would overlap them, so the points of single bounds shared by
+// two Open intervals, such as 10 between (0,10) and (10,20), are not reported.
+//
+// Intervals that end within an already covered range are skipped using the level pointers.
+// The list must not be modified during iteration.
+func (sl *SkipList[T, V]) Gaps(interval IntervalKey[T, V]) iter.Seq[IntervalKey[T, V]] {
+	return func(yield func(IntervalKey[T, V]) bool) {
+		closed := sl.bounds == Closed
+		reach := interval.Start // Everything before reach is covered or already yielded.
+		afterEnd := false       // Whether reach is the End of an interval rather than the query Start.
+		n, pos := sl.nextOverlap(sl.head, 0, reach, true)
+		for ; n != nil && sl.before(n.intervalKey.Start, interval.End, closed); n, pos = sl.nextOverlap(n, pos, reach, true) {
+			if gap, ok := sl.gap(reach, n.intervalKey.Start, afterEnd, true); ok {
+				if !yield(gap) {
+					return
+				}
+			}
+			if sl.before(reach, n.intervalKey.End, closed) {
+				reach, afterEnd = n.intervalKey.End, true
+			}
+			if sl.compare(reach, interval.End) >= 0 {
+				return
+			}
+		}
+		if gap, ok := sl.gap(reach, interval.End, afterEnd, false); ok {
+			yield(gap)
+		}
+	}
+}
+
+// gap returns the gap from lo, the End of an interval if afterEnd or the query Start otherwise,
+// to hi, the Start of an interval if beforeStart or the query End otherwise, see Gaps.
+// Returns false if the gap is empty.
+func (sl *SkipList[T, V]) gap(lo, hi T, afterEnd, beforeStart bool) (IntervalKey[T, V], bool) {
+	if sl.bounds != Closed || sl.adjacent == nil {
+		return IntervalKey[T, V]{Start: lo, End: hi}, sl.compare(lo, hi) < 0
+	}
+	// The bounds of the neighbours are covered, so the gap starts and ends next to them.
+	ok := true
+	if afterEnd {
+		lo, ok = sl.adjacent(lo, true)
+	}
+	if ok && beforeStart {
+		hi, ok = sl.adjacent(hi, false)
+	}
+	return IntervalKey[T, V]{Start: lo, End: hi}, ok && sl.compare(lo, hi) <= 0
+}
+
+// FirstFit returns the earliest free slot of length minLen that starts at or after the point
+// after and does not overlap any interval in the list, so InsertExclusive accepts it whatever the
+// list Bounds. In Closed lists, where intervals that share a bound overlap, a slot starts after the
+// End of the interval before it, at the next value of T, and ends before the Start of the next.
+// Returns false if minLen is negative or the slot would overflow T.
+func FirstFit[T Number, V any](sl *SkipList[T, V], minLen, after T) (IntervalKey[T, V], bool) {
+	var zero T
+	if minLen < zero {
+		return IntervalKey[T, V]{}, false
+	}
+	closed := sl.bounds == Closed // Whether the slot must not share a bound with an interval.
+	start := after
+	n, pos := sl.nextOverlap(sl.head, 0, start, closed)
+	for ; n != nil; n, pos = sl.nextOverlap(n, pos, start, closed) {
+		end := start + minLen
+		if end < start {
+			return IntervalKey[T, V]{}, false
+		}
+		if sl.compare(n.intervalKey.Start, start) > 0 && sl.before(end, n.intervalKey.Start, !closed) {
+			break
+		}
+		if sl.before(start, n.intervalKey.End, closed) {
+			start = n.intervalKey.End
+			if closed {
+				next, ok := successor(start)
+				if !ok {
+					return IntervalKey[T, V]{}, false
+				}
+				start = next
+			}
+		}
+	}
+	if start+minLen < start {
+		return IntervalKey[T, V]{}, false
+	}
+	return IntervalKey[T, V]{Start: start, End: start + minLen}, true
+}
+
+// successor returns the smallest value of T greater than x.
+// Returns false if there is none.
+func successor[T Number](x T) (T, bool) {
+	half := 0.5
+	if T(half) == 0 {
+		// An integer type.
+		return x + 1, x+1 > x
+	}
+	var next T
+	if unsafe.Sizeof(x) == 4 {
+		next = T(math.Nextafter32(float32(x), float32(math.Inf(1))))
+	} else {
+		next = T(math.Nextafter(float64(x), math.Inf(1)))
+	}
+	return next, next > x
+}
+
+// predecessor returns the largest value of T less than x.
+// Returns false if there is none.
+func predecessor[T Number](x T) (T, bool) {
+	half := 0.5
+	if T(half) == 0 {
+		// An integer type.
+		return x - 1, x-1 < x
+	}
+	var prev T
+	if unsafe.Sizeof(x) == 4 {
+		prev = T(math.Nextafter32(float32(x), float32(math.Inf(-1))))
+	} else {
+		prev = T(math.Nextafter(float64(x), math.Inf(-1)))
+	}
+	return prev, prev < x
+}
+
+// adjacent returns the value of T next to x, greater than x if up, or less than x otherwise.
+// Returns false if there is none.
+func adjacent[T Number](x T, up bool) (T, bool) {
+	if up {
+		return successor(x)
+	}
+	return predecessor(x)
+}
+
+// adjacentAs returns the value next to x of a type T whose underlying type is N, see adjacent.
+func adjacentAs[T any, N Number](x T, up bool) (T, bool) {
+	n, ok := adjacent(*(*N)(unsafe.Pointer(&x)), up)
+	return *(*T)(unsafe.Pointer(&n)), ok
+}
+
+// adjacentFunc returns adjacent for T, or nil if the underlying type of T is not a Number.
+func adjacentFunc[T any]() func(x T, up bool) (T, bool) {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int:
+		return adjacentAs[T, int]
+	case reflect.Int8:
+		return adjacentAs[T, int8]
+	case reflect.Int16:
+		return adjacentAs[T, int16]
+	case reflect.Int32:
+		return adjacentAs[T, int32]
+	case reflect.Int64:
+		return adjacentAs[T, int64]
+	case reflect.Uint:
+		return adjacentAs[T, uint]
+	case reflect.Uint8:
+		return adjacentAs[T, uint8]
+	case reflect.Uint16:
+		return adjacentAs[T, uint16]
+	case reflect.Uint32:
+		return adjacentAs[T, uint32]
+	case reflect.Uint64:
+		return adjacentAs[T, uint64]
+	case reflect.Uintptr:
+		return adjacentAs[T, uintptr]
+	case reflect.Float32:
+		return adjacentAs[T, float32]
+	case reflect.Float64:
+		return adjacentAs[T, float64]
+	}
+	return nil
+}
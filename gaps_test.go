@@ -0,0 +1,178 @@
+package islist
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func bounds(keys []IntervalKey[int, string]) [][2]int {
+	r := make([][2]int, len(keys))
+	for i, k := range keys {
+		r[i] = [2]int{k.Start, k.End}
+	}
+	return r
+}
+
+func newGapsTestList() *SkipList[int, string] {
+	list := newTestList()
+	list.Insert(NewIntervalKey(10, 20, "a"))
+	list.Insert(NewIntervalKey(12, 15, "b"))
+	list.Insert(NewIntervalKey(15, 30, "c"))
+	list.Insert(NewIntervalKey(40, 50, "d"))
+	list.Insert(NewIntervalKey(52, 55, "e"))
+	list.Insert(NewIntervalKey(60, 100, "f"))
+	list.Insert(NewIntervalKey(70, 80, "g"))
+	return list
+}
+
+func TestGaps(t *testing.T) {
+	tests := []struct {
+		query    IntervalKey[int, string]
+		closed   [][2]int // Gaps in Closed lists don't share a bound with an interval.
+		halfOpen [][2]int
+	}{
+		{
+			query:    NewIntervalQuery[int, string](0, 120),
+			closed:   [][2]int{{0, 9}, {31, 39}, {51, 51}, {56, 59}, {101, 120}},
+			halfOpen: [][2]int{{0, 10}, {30, 40}, {50, 52}, {55, 60}, {100, 120}},
+		},
+		{query: NewIntervalQuery[int, string](12, 45), closed: [][2]int{{31, 39}}, halfOpen: [][2]int{{30, 40}}},
+		{query: NewIntervalQuery[int, string](65, 90), closed: [][2]int{}, halfOpen: [][2]int{}},
+		{query: NewIntervalQuery[int, string](31, 39), closed: [][2]int{{31, 39}}, halfOpen: [][2]int{{31, 39}}},
+		{query: NewIntervalQuery[int, string](30, 40), closed: [][2]int{{31, 39}}, halfOpen: [][2]int{{30, 40}}},
+		{query: NewIntervalQuery[int, string](51, 51), closed: [][2]int{{51, 51}}, halfOpen: [][2]int{}},
+		{query: NewIntervalQuery[int, string](200, 300), closed: [][2]int{{200, 300}}, halfOpen: [][2]int{{200, 300}}},
+	}
+	closed, halfOpen := newGapsTestList(), newGapsTestList()
+	halfOpen.bounds = HalfOpen
+	for _, test := range tests {
+		if got := bounds(slices.Collect(closed.Gaps(test.query))); !slices.Equal(got, test.closed) {
+			t.Errorf("Closed Gaps(%s): expected %v. got %v", test.query, test.closed, got)
+		}
+		if got := bounds(slices.Collect(halfOpen.Gaps(test.query))); !slices.Equal(got, test.halfOpen) {
+			t.Errorf("HalfOpen Gaps(%s): expected %v. got %v", test.query, test.halfOpen, got)
+		}
+	}
+
+	t.Run("Gaps of empty list", func(t *testing.T) {
+		got := bounds(slices.Collect(newTestList().Gaps(NewIntervalQuery[int, string](0, 10))))
+		if !slices.Equal(got, [][2]int{{0, 10}}) {
+			t.Errorf("expected [[0 10]]. got %v", got)
+		}
+	})
+
+	t.Run("Gaps can be inserted exclusively", func(t *testing.T) {
+		for _, b := range []Bounds{Closed, HalfOpen, LeftOpen, Open} {
+			list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithBounds(b))
+			list.Insert(NewIntervalKey(0, 10, "a"))
+			list.Insert(NewIntervalKey(10, 20, "b"))
+			list.Insert(NewIntervalKey(25, 30, "c"))
+			list.Insert(NewIntervalKey(31, 40, "d"))
+			gaps := slices.Collect(list.Gaps(NewIntervalQuery[int, string](-5, 50)))
+			for _, gap := range gaps {
+				if _, err := list.InsertExclusive(gap); err != nil {
+					t.Errorf("%s: expected gap %s to be inserted. got %v", b, gap, err)
+				}
+			}
+			if gaps := slices.Collect(list.Gaps(NewIntervalQuery[int, string](-5, 50))); len(gaps) != 0 {
+				t.Errorf("%s: expected no gaps left. got %v", b, gaps)
+			}
+		}
+	})
+
+	t.Run("Closed gaps are not covered", func(t *testing.T) {
+		list := newGapsTestList()
+		var uncovered []int
+		for gap := range list.Gaps(NewIntervalQuery[int, string](0, 120)) {
+			for p := gap.Start; p <= gap.End; p++ {
+				uncovered = append(uncovered, p)
+			}
+		}
+		for p := 0; p <= 120; p++ {
+			if covered := list.Covers(p); covered == slices.Contains(uncovered, p) {
+				t.Fatalf("expected %d covered %t. got %t", p, !covered, covered)
+			}
+		}
+	})
+
+	t.Run("Float gaps", func(t *testing.T) {
+		list := New(NewNodePool[float64, string](), rand.NewPCG(2, 3))
+		list.Insert(NewIntervalKey(0.0, 1.0, "a"))
+		list.Insert(NewIntervalKey(2.0, 3.0, "b"))
+		gaps := slices.Collect(list.Gaps(NewIntervalQuery[float64, string](0, 3)))
+		if len(gaps) != 1 || gaps[0].Start != math.Nextafter(1, 2) || gaps[0].End != math.Nextafter(2, 1) {
+			t.Errorf("expected a gap next to 1 and 2. got %v", gaps)
+		}
+	})
+}
+
+func TestFirstFit(t *testing.T) {
+	tests := []struct {
+		minLen, after int
+		closed        [2]int // Slots in Closed lists don't share a bound with an interval.
+		halfOpen      [2]int
+	}{
+		{minLen: 5, after: 0, closed: [2]int{0, 5}, halfOpen: [2]int{0, 5}},
+		{minLen: 5, after: 8, closed: [2]int{31, 36}, halfOpen: [2]int{30, 35}},
+		{minLen: 10, after: 8, closed: [2]int{101, 111}, halfOpen: [2]int{30, 40}},
+		{minLen: 3, after: 45, closed: [2]int{56, 59}, halfOpen: [2]int{55, 58}},
+		{minLen: 11, after: 8, closed: [2]int{101, 112}, halfOpen: [2]int{100, 111}},
+		{minLen: 2, after: 50, closed: [2]int{56, 58}, halfOpen: [2]int{50, 52}},
+	}
+	closed, halfOpen := newGapsTestList(), newGapsTestList()
+	halfOpen.bounds = HalfOpen
+	for _, test := range tests {
+		if ik, ok := FirstFit(closed, test.minLen, test.after); !ok || [2]int{ik.Start, ik.End} != test.closed {
+			t.Errorf("Closed FirstFit(%d, %d): expected %v. got %s, %t", test.minLen, test.after, test.closed, ik, ok)
+		}
+		if ik, ok := FirstFit(halfOpen, test.minLen, test.after); !ok || [2]int{ik.Start, ik.End} != test.halfOpen {
+			t.Errorf("HalfOpen FirstFit(%d, %d): expected %v. got %s, %t", test.minLen, test.after, test.halfOpen, ik, ok)
+		}
+	}
+
+	t.Run("Slots can be inserted exclusively", func(t *testing.T) {
+		for _, b := range []Bounds{Closed, HalfOpen, LeftOpen, Open} {
+			list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithBounds(b))
+			list.Insert(NewIntervalKey(0, 10, "a"))
+			list.Insert(NewIntervalKey(20, 30, "b"))
+			for i := 0; i < 20; i++ {
+				ik, ok := FirstFit(list, 1+i%7, i%25)
+				if !ok {
+					t.Fatalf("%s: expected a slot", b)
+				}
+				if _, err := list.InsertExclusive(ik); err != nil {
+					t.Fatalf("%s: expected slot %s to be inserted. got %v", b, ik, err)
+				}
+			}
+		}
+	})
+
+	t.Run("Negative length", func(t *testing.T) {
+		if ik, ok := FirstFit(newGapsTestList(), -1, 0); ok {
+			t.Errorf("expected no slot. got %s", ik)
+		}
+	})
+
+	t.Run("Float slots", func(t *testing.T) {
+		list := New(NewNodePool[float64, string](), rand.NewPCG(2, 3))
+		list.Insert(NewIntervalKey(0.0, 1.0, "a"))
+		ik, ok := FirstFit(list, 0.5, 0)
+		if !ok || ik.Start <= 1 || ik.Start != math.Nextafter(1, 2) {
+			t.Errorf("expected slot right after 1. got %s, %t", ik, ok)
+		}
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		list := New(NewNodePool[int8, string](), rand.NewPCG(2, 3))
+		list.Insert(NewIntervalKey[int8](0, 120, "a"))
+		if ik, ok := FirstFit(list, 10, 0); ok {
+			t.Errorf("expected no slot. got %s", ik)
+		}
+		list.Insert(NewIntervalKey[int8](0, math.MaxInt8, "b"))
+		if ik, ok := FirstFit(list, 0, 0); ok {
+			t.Errorf("expected no slot. got %s", ik)
+		}
+	})
+}
@@ -1,6 +1,10 @@
 package islist
 
-import "fmt"
+import (
+	"cmp"
+	"errors"
+	"fmt"
+)
 
 const (
 	IntervalEqual    = 0
@@ -8,47 +12,102 @@ const (
 	IntervalGreater  = 1
 )
 
+var (
+	ErrInvalidInterval = errors.New("invalid interval")
+	ErrNegativeBound   = errors.New("negative interval bound")
+)
+
+// Bounds represent whether the bounds of the intervals in a list are included in the intervals.
+// The bounds apply to both the intervals in the list and the query intervals and points.
+// The pieces left by SkipList.Subtract and the gaps of SkipList.Gaps follow the bounds too.
+type Bounds uint8
+
+const (
+	Closed   Bounds = iota // [Start, End], the default.
+	HalfOpen               // [Start, End), intervals must not be empty.
+	Open                   // (Start, End), intervals must not be empty.
+	LeftOpen               // (Start, End], intervals must not be empty.
+)
+
+func (b Bounds) String() string {
+	switch b {
+	case Closed:
+		return "closed"
+	case HalfOpen:
+		return "half-open"
+	case Open:
+		return "open"
+	case LeftOpen:
+		return "left-open"
+	}
+	return fmt.Sprintf("Bounds(%d)", b)
+}
+
+// includesStart reports whether intervals include their Start.
+func (b Bounds) includesStart() bool {
+	return b == Closed || b == HalfOpen
+}
+
+// includesEnd reports whether intervals include their End.
+func (b Bounds) includesEnd() bool {
+	return b == Closed || b == LeftOpen
+}
+
 // IntervalKey represent a key in the list with an associated interval.
-type IntervalKey struct {
-	Start, End int64
-	Key        string
+// The interval bounds are of type T and the Key holds the caller's payload of type V.
+type IntervalKey[T, V any] struct {
+	Start, End T
+	Key        V
 }
 
 // NewIntervalKey returns a new IntervalKey to insert into a list.
-func NewIntervalKey(start, end int64, key string) IntervalKey {
-	if start < 0 || end < 0 || (start > end) {
-		panic("interval Start and End must be a positive number and Start must be < End")
+// Bounds may be negative, but Start must be <= End. It panics otherwise, see NewIntervalKeyE.
+func NewIntervalKey[T cmp.Ordered, V any](start, end T, key V) IntervalKey[T, V] {
+	ik, err := NewIntervalKeyE(start, end, key)
+	if err != nil {
+		panic(err.Error())
+	}
+	return ik
+}
+
+// NewIntervalKeyE returns a new IntervalKey to insert into a list.
+// Returns an ErrInvalidInterval error if Start > End or a bound is NaN.
+func NewIntervalKeyE[T cmp.Ordered, V any](start, end T, key V) (IntervalKey[T, V], error) {
+	if isNaN(start) || isNaN(end) {
+		return IntervalKey[T, V]{}, fmt.Errorf("%w: [%v,%v]: NaN bound", ErrInvalidInterval, start, end)
+	}
+	if start > end {
+		return IntervalKey[T, V]{}, fmt.Errorf("%w: [%v,%v]: Start must be <= End", ErrInvalidInterval, start, end)
 	}
-	return IntervalKey{
+	return IntervalKey[T, V]{
 		Start: start,
 		End:   end,
 		Key:   key,
-	}
+	}, nil
 }
 
 // NewQueryInterval returns an IntervalKey used in interval queries.
 // This is a convience to avoid having separate types which would require a common interface.
-func NewIntervalQuery(start, end int64) IntervalKey {
-	return NewIntervalKey(start, end, "")
+// The key is left as the zero value of V.
+func NewIntervalQuery[T cmp.Ordered, V any](start, end T) IntervalKey[T, V] {
+	var key V
+	return NewIntervalKey(start, end, key)
 }
 
-func (i IntervalKey) String() string {
-	return fmt.Sprintf("{interval: [%d,%d], key: %s", i.Start, i.End, i.Key)
+func (i IntervalKey[T, V]) String() string {
+	return fmt.Sprintf("{interval: [%v,%v], key: %v", i.Start, i.End, i.Key)
 }
 
 // equalInterval checks if two intervals are considered identical.
-func (i IntervalKey) equalInterval(i2 IntervalKey) bool {
-	return i.Start == i2.Start && i.End == i2.End
+func (i IntervalKey[T, V]) equalInterval(i2 IntervalKey[T, V], compare func(a, b T) int) bool {
+	return compare(i.Start, i2.Start) == IntervalEqual && compare(i.End, i2.End) == IntervalEqual
 }
 
 // less compares the order of intervals a and b by their Start.
 // It compares End if the Start of a and b are equal.
-func less(a, b IntervalKey) bool {
-	if a.Start < b.Start {
-		return true
-	}
-	if a.Start > b.Start {
-		return false
+func less[T, V any](compare func(a, b T) int, a, b IntervalKey[T, V]) bool {
+	if c := compare(a.Start, b.Start); c != IntervalEqual {
+		return c < 0
 	}
-	return a.End < b.End
+	return compare(a.End, b.End) < 0
 }
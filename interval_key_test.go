@@ -1,6 +1,14 @@
 package islist
 
-import "testing"
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
 
 func TestNewIntervalKeySuccess(t *testing.T) {
 	s, e := int64(5), int64(10)
@@ -15,26 +23,24 @@ func TestIntervalEqual(t *testing.T) {
 	t.Run("Interval a should equal b", func(t *testing.T) {
 		iv1 := NewIntervalKey(s, e, "key1")
 		iv2 := NewIntervalKey(s, e, "key2")
-		if iv1.equalInterval(iv2) != true {
+		if iv1.equalInterval(iv2, cmp.Compare[int64]) != true {
 			t.Errorf("expected %s to equal %s", iv1, iv2)
 		}
 	})
 	t.Run("Interval a should not equal b", func(t *testing.T) {
 		iv1 := NewIntervalKey(s, e, "key")
 		iv2 := NewIntervalKey(s+1, e, "key")
-		if iv1.equalInterval(iv2) == true {
+		if iv1.equalInterval(iv2, cmp.Compare[int64]) == true {
 			t.Errorf("expected %s to not equal %s", iv1, iv2)
 		}
 	})
 }
 
-func TestNegativeIntervalPanic(t *testing.T) {
-	defer func() {
-		if r := recover(); r == nil {
-			t.Errorf("expected panic for negative interval")
-		}
-	}()
-	_ = NewIntervalKey(-5, 10, "key")
+func TestNegativeInterval(t *testing.T) {
+	iv := NewIntervalKey(-10, -5, "key")
+	if iv.Start != -10 || iv.End != -5 {
+		t.Errorf("expected [-10, -5], got %s", iv)
+	}
 }
 
 func TestEndBeforeStartIntervalPanic(t *testing.T) {
@@ -48,19 +54,53 @@ func TestEndBeforeStartIntervalPanic(t *testing.T) {
 
 func TestLess(t *testing.T) {
 	tests := []struct {
-		A        IntervalKey
-		B        IntervalKey
+		A        IntervalKey[int, string]
+		B        IntervalKey[int, string]
 		Expected bool
 	}{
-		{IntervalKey{Start: 10, End: 20}, IntervalKey{Start: 25, End: 35}, true},
-		{IntervalKey{Start: 24, End: 35}, IntervalKey{Start: 10, End: 20}, false},
-		{IntervalKey{Start: 10, End: 20}, IntervalKey{Start: 10, End: 25}, true},
-		{IntervalKey{Start: 10, End: 25}, IntervalKey{Start: 10, End: 20}, false},
+		{IntervalKey[int, string]{Start: 10, End: 20}, IntervalKey[int, string]{Start: 25, End: 35}, true},
+		{IntervalKey[int, string]{Start: 24, End: 35}, IntervalKey[int, string]{Start: 10, End: 20}, false},
+		{IntervalKey[int, string]{Start: 10, End: 20}, IntervalKey[int, string]{Start: 10, End: 25}, true},
+		{IntervalKey[int, string]{Start: 10, End: 25}, IntervalKey[int, string]{Start: 10, End: 20}, false},
 	}
 	for _, test := range tests {
-		result := less(test.A, test.B)
+		result := less(cmp.Compare[int], test.A, test.B)
 		if result != test.Expected {
 			t.Errorf("Less(%+v, %+v): expected %t, got %t", test.A, test.B, test.Expected, result)
 		}
 	}
 }
+
+func TestLessFunc(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := IntervalKey[time.Time, string]{Start: t0, End: t0.Add(time.Hour)}
+	b := IntervalKey[time.Time, string]{Start: t0, End: t0.Add(2 * time.Hour)}
+	if !less(time.Time.Compare, a, b) {
+		t.Errorf("Less(%s, %s): expected true", a, b)
+	}
+	if less(time.Time.Compare, b, a) {
+		t.Errorf("Less(%s, %s): expected false", b, a)
+	}
+}
+
+func TestNewIntervalKeyE(t *testing.T) {
+	tests := []struct {
+		start, end float64
+		err        error
+	}{
+		{start: -5, end: 10, err: nil},
+		{start: 5, end: 5, err: nil},
+		{start: 10, end: 5, err: ErrInvalidInterval},
+		{start: math.NaN(), end: 5, err: ErrInvalidInterval},
+		{start: 0, end: math.NaN(), err: ErrInvalidInterval},
+	}
+	for _, test := range tests {
+		_, err := NewIntervalKeyE(test.start, test.end, "key")
+		if !errors.Is(err, test.err) {
+			t.Errorf("NewIntervalKeyE(%v, %v): expected %v. got %v", test.start, test.end, test.err, err)
+		}
+		if nan := math.IsNaN(test.start) || math.IsNaN(test.end); nan && !strings.Contains(fmt.Sprint(err), "NaN") {
+			t.Errorf("NewIntervalKeyE(%v, %v): expected a NaN error. got %v", test.start, test.end, err)
+		}
+	}
+}
@@ -5,81 +5,312 @@
 package islist
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
 	"io"
 	"math"
+	"math/bits"
 	"math/rand/v2"
-	"os"
+	"slices"
 )
 
+// Default level configuration of a list, see WithMaxLevel and WithProbability.
+// MaxLevel is also the upper bound of WithMaxLevel and WithSearchLevelCap.
 const (
-	MaxLevel               = 32   // log_(4)(2^64) = 32.
-	MaxSearchLevel         = 10   // log_(4)(10^6) = ~10
-	Probability    float32 = 0.25 // P = 1/4
-	levelThreshold int32   = int32(Probability * math.MaxInt32)
+	MaxLevel            = 32   // log_(4)(2^64) = 32.
+	Probability float32 = 0.25 // P = 1/4
 )
 
-// SkipList represent an Interval Skiplist probabilistic data structure for contiguous intervals.
+// MaxSearchLevel is a search level cap tuned for lists of about 10^6 elements, see WithSearchLevelCap.
+//
+// Deprecated: By default the search level cap adapts to the list length.
+const MaxSearchLevel = 10 // log_(4)(10^6) = ~10
+
+// ErrOverlap is returned, wrapped in an OverlapError, when an interval overlaps existing intervals.
+var ErrOverlap = errors.New("interval overlaps existing intervals")
+
+// OverlapError represent an error for an interval that overlaps existing intervals in the list.
+type OverlapError[T, V any] struct {
+	Interval  IntervalKey[T, V]
+	Conflicts []IntervalKey[T, V] // The overlapping intervals in list order.
+}
+
+func (e *OverlapError[T, V]) Error() string {
+	return fmt.Sprintf("interval [%v,%v] overlaps %d existing intervals", e.Interval.Start, e.Interval.End, len(e.Conflicts))
+}
+
+func (e *OverlapError[T, V]) Unwrap() error {
+	return ErrOverlap
+}
+
+// SkipList represent an Interval Skiplist probabilistic data structure for possibly overlapping intervals.
 //
 // A Skiplist assigns levels to nodes randomly using a geometric distribution.
 // Each level (k) has nodes with probability (p^k), where (p) is the probability factor.
 // The theoretical maximum level (L) of a skiplist grows logarithmically with the number
 // of elements (n): L = log_(1/p)(n)
-type SkipList struct {
-	head     *Node
-	maxLevel int
-	length   int
-	pool     *NodePool
-	PCG      *rand.PCG
-}
-
-// New returns a new instance of a SkipList.
-func New(pool *NodePool, PCG *rand.PCG) *SkipList {
-	return &SkipList{
-		head:     newNode(pool, MaxLevel, IntervalKey{}),
+type SkipList[T, V any] struct {
+	head        *Node[T, V]
+	tail        *Node[T, V]
+	maxLevel    int
+	length      int
+	pool        NodeAllocator[T, V]
+	compare     func(a, b T) int
+	merge       func(a, b V) V // Merges keys in coalescing mode, nil otherwise.
+	bounds      Bounds
+	nonNegative bool         // Whether TryInsert rejects negative bounds.
+	isNaN       func(T) bool // Reports NaN bounds, which TryInsert rejects, for lists of ordered types.
+	debug       func(error)  // Called with any invariant violation after a modification, see WithDebugHook.
+	levelCap    int          // Maximum level of a node, and number of head levels.
+	searchCap   int          // Maximum number of levels searched by lookups, or 0 to adapt to the length.
+	levelScale  float64      // 1/log2(1/p), converts a log2 of the length to the expected number of levels.
+	levels      LevelGenerator
+	adjacent    func(x T, up bool) (T, bool) // The bound next to x for lists of numbers, nil otherwise.
+	mods        uint64                       // Number of modifications, see Cursor.
+}
+
+// New returns a new instance of a SkipList with intervals bounded by an ordered type,
+// configured by any options. Node levels are drawn from the PCG, see NewRandomLevels, unless the
+// list is created WithLevelGenerator, in which case the PCG may be nil.
+func New[T cmp.Ordered, V any](pool NodeAllocator[T, V], PCG *rand.PCG, opts ...Option) *SkipList[T, V] {
+	return ordered(NewFunc(pool, PCG, cmp.Compare[T], opts...))
+}
+
+// ordered configures a list whose bounds are of an ordered type, compared by cmp.Compare.
+func ordered[T cmp.Ordered, V any](sl *SkipList[T, V]) *SkipList[T, V] {
+	sl.isNaN = isNaN[T]
+	sl.adjacent = adjacentFunc[T]()
+	return sl
+}
+
+// isNaN reports whether x is a floating-point NaN.
+func isNaN[T cmp.Ordered](x T) bool {
+	return x != x
+}
+
+// NewFunc returns a new instance of a SkipList that orders interval bounds using the compare function.
+// The compare function must return a negative number when a < b, a positive number when a > b
+// and zero when a == b, e.g. time.Time.Compare.
+func NewFunc[T, V any](pool NodeAllocator[T, V], PCG *rand.PCG, compare func(a, b T) int, opts ...Option) *SkipList[T, V] {
+	sl := &SkipList[T, V]{
 		maxLevel: 1,
 		length:   0,
 		pool:     pool,
-		PCG:      PCG,
+		compare:  compare,
 	}
+	sl.apply(PCG, opts)
+	sl.head = newNode(pool, sl.levelCap, IntervalKey[T, V]{})
+	return sl
+}
+
+// newEmpty returns a new empty list with the same configuration as the list.
+func (sl *SkipList[T, V]) newEmpty() *SkipList[T, V] {
+	e := *sl
+	e.head = newNode(sl.pool, sl.levelCap, IntervalKey[T, V]{})
+	e.tail = nil
+	e.maxLevel = 1
+	e.length = 0
+	return &e
 }
 
 // QueryParam represent parameters used in list queries.
 type QueryParam struct {
+	// Offset skips the first overlaps of a query.
+	//
+	// Deprecated: Each page rescans all prior overlaps, use OverlapsPage to resume from a PageToken.
 	Offset int
 	Limit  int
 }
 
+// PageToken represents the position after the last key of a page of results, see OverlapsPage.
+// The zero value starts from the first page.
+type PageToken[T any] struct {
+	Start, End T    // The interval of the last key of the page.
+	Valid      bool // Whether the token resumes after a key, false for the first page or when done.
+}
+
 // newNode returns a new instance of a node.
-func newNode(pool *NodePool, level int, ik IntervalKey) *Node {
-	n := pool.get()
-	if cap(n.levels) >= level {
-		// Reuse any preallocated capacity.
-		n.levels = n.levels[:level]
-	} else {
-		n.levels = make([]nodeLevel, level)
-	}
+func newNode[T, V any](pool NodeAllocator[T, V], level int, ik IntervalKey[T, V]) *Node[T, V] {
+	n := pool.get(level)
 	n.intervalKey = ik
 	return n
 }
 
-// randomLevel returns a random level.
-func (sl *SkipList) randomLevel() int {
-	r := rand.New(sl.PCG)
-	level := 1
-	for r.Int32() < levelThreshold && level < MaxLevel {
-		level++
-	}
-	return level
+// randomLevel returns the level of a new node from the list's level generator.
+func (sl *SkipList[T, V]) randomLevel() int {
+	return min(max(sl.levels.Level(sl.levelCap), 1), sl.levelCap)
 }
 
 // Insert adds a new key to the list.
 // If the key already exist, it updates the existing key and returns the previous key.
-func (sl *SkipList) Insert(intervalKey IntervalKey) *IntervalKey {
-	var n *Node
+//
+// In coalescing mode, see WithCoalesce, the key is merged with all keys whose interval it overlaps
+// or touches, and the first of the merged keys is returned.
+func (sl *SkipList[T, V]) Insert(intervalKey IntervalKey[T, V]) *IntervalKey[T, V] {
+	k, ok := sl.InsertKey(intervalKey)
+	if !ok {
+		return nil
+	}
+	prev := k
+	return &prev
+}
+
+// InsertKey adds a new key to the list, see Insert.
+// If the key already exist, it updates the existing key and returns the previous key and true.
+// Unlike Insert, it doesn't allocate in the steady state, outside of coalescing mode.
+func (sl *SkipList[T, V]) InsertKey(intervalKey IntervalKey[T, V]) (IntervalKey[T, V], bool) {
+	defer sl.check()
+	if sl.merge != nil {
+		_, prev, ok := sl.coalesce(intervalKey)
+		return prev, ok
+	}
+	return sl.insert(intervalKey)
+}
+
+// TryInsert validates the key's interval before adding it to the list, see Insert.
+// Returns an ErrInvalidInterval error if Start > End, if the interval is empty and the list
+// bounds are not Closed, or if a bound is NaN and the list was created with New or
+// NewFromSorted. Returns an ErrNegativeBound error for a negative bound if the list was created
+// WithNonNegativeBounds.
+func (sl *SkipList[T, V]) TryInsert(intervalKey IntervalKey[T, V]) (*IntervalKey[T, V], error) {
+	if err := sl.validate(intervalKey); err != nil {
+		return nil, err
+	}
+	return sl.Insert(intervalKey), nil
+}
+
+// validate checks that the key's interval is valid for the list.
+func (sl *SkipList[T, V]) validate(ik IntervalKey[T, V]) error {
+	if sl.isNaN != nil && (sl.isNaN(ik.Start) || sl.isNaN(ik.End)) {
+		return fmt.Errorf("%w: [%v,%v]: NaN bound", ErrInvalidInterval, ik.Start, ik.End)
+	}
+	c := sl.compare(ik.Start, ik.End)
+	if c > 0 {
+		return fmt.Errorf("%w: [%v,%v]: Start must be <= End", ErrInvalidInterval, ik.Start, ik.End)
+	}
+	if c == 0 && sl.bounds != Closed {
+		return fmt.Errorf("%w: [%v,%v]: empty %s interval", ErrInvalidInterval, ik.Start, ik.End, sl.bounds)
+	}
+	var zero T
+	if sl.nonNegative && sl.compare(ik.Start, zero) < 0 {
+		return fmt.Errorf("%w: [%v,%v]", ErrNegativeBound, ik.Start, ik.End)
+	}
+	return nil
+}
+
+// InsertExclusive adds a new key to the list if its interval doesn't overlap any existing interval.
+// Otherwise the list is left unchanged and it returns the conflicting keys, in list order, and an
+// *OverlapError that wraps ErrOverlap. This makes double bookings impossible.
+// The key's interval is validated first, returning the same errors as TryInsert.
+//
+// In coalescing mode, see WithCoalesce, the key is merged with the keys whose interval only
+// touches it, as they don't overlap it.
+//
+// The first conflict is found in O(log n), with one more step per additional conflict.
+func (sl *SkipList[T, V]) InsertExclusive(intervalKey IntervalKey[T, V]) (conflicts []IntervalKey[T, V], err error) {
+	if err := sl.validate(intervalKey); err != nil {
+		return nil, err
+	}
+	inclusive := sl.bounds == Closed
+	n, pos := sl.nextOverlap(sl.head, 0, intervalKey.Start, inclusive)
+	for ; n != nil && sl.before(n.intervalKey.Start, intervalKey.End, inclusive); n, pos = sl.nextOverlap(n, pos, intervalKey.Start, inclusive) {
+		conflicts = append(conflicts, n.intervalKey)
+	}
+	if len(conflicts) > 0 {
+		return conflicts, &OverlapError[T, V]{Interval: intervalKey, Conflicts: conflicts}
+	}
+	if sl.merge != nil {
+		sl.coalesce(intervalKey)
+	} else {
+		sl.insert(intervalKey)
+	}
+	sl.check()
+	return nil, nil
+}
+
+// coalesce inserts the key merged with all keys whose interval it overlaps or touches.
+// Returns the inserted key, and the first merged key if any.
+func (sl *SkipList[T, V]) coalesce(intervalKey IntervalKey[T, V]) (ik, prev IntervalKey[T, V], ok bool) {
+	// Touching intervals are merged unless their shared bound is excluded from both.
+	merged := sl.deleteOverlapping(intervalKey, sl.bounds != Open)
+	if len(merged) == 0 {
+		prev, ok = sl.insert(intervalKey)
+		return intervalKey, prev, ok
+	}
+	ik = merged[0]
+	for _, k := range merged[1:] {
+		ik.Key = sl.merge(ik.Key, k.Key)
+		if sl.compare(k.End, ik.End) > 0 {
+			ik.End = k.End
+		}
+	}
+	ik.Key = sl.merge(ik.Key, intervalKey.Key)
+	if sl.compare(intervalKey.Start, ik.Start) < 0 {
+		ik.Start = intervalKey.Start
+	}
+	if sl.compare(intervalKey.End, ik.End) > 0 {
+		ik.End = intervalKey.End
+	}
+	sl.insert(ik)
+	return ik, merged[0], true
+}
+
+// Subtract removes the range of the interval from the list, trimming or splitting the intervals
+// that overlap it. Split intervals keep their key. Returns the previous keys of the intervals that
+// were trimmed, split or removed, in list order.
+//
+// The interval and the pieces follow the list Bounds, so the pieces don't overlap the interval.
+// In Closed lists of numbers, created with New or NewFromSorted, a piece ends or starts at the
+// value next to a bound of the interval, e.g. [0,20] - [5,10] = [0,4], [11,20], and intervals
+// that only touch the interval are trimmed. Other bound types have no next value, so in Closed
+// lists of those, the pieces share the bounds of the interval and intervals that only touch it
+// are left as is. In Open lists the pieces share the bounds of the interval, e.g.
+// (0,20) - (5,10) = (0,5), (10,20), as an open piece that includes 5 or 10 would overlap it.
+//
+// A trimmed or split piece never overwrites a key: a piece with the same bounds as an interval
+// that remains in the list is dropped, and of pieces with the same bounds, the piece of the first
+// affected interval is kept.
+func (sl *SkipList[T, V]) Subtract(interval IntervalKey[T, V]) []IntervalKey[T, V] {
+	// The End of the pieces before the interval and the Start of the pieces after it.
+	end, start := interval.Start, interval.End
+	hasEnd, hasStart := true, true
+	adjusted := sl.bounds == Closed && sl.adjacent != nil
+	if adjusted {
+		end, hasEnd = sl.adjacent(interval.Start, false)
+		start, hasStart = sl.adjacent(interval.End, true)
+	}
+	removed := sl.DeleteOverlapping(interval)
+	var affected, pieces []IntervalKey[T, V]
+	for _, k := range removed {
+		if !adjusted && (sl.compare(k.End, interval.Start) <= 0 || sl.compare(k.Start, interval.End) >= 0) {
+			sl.insert(k) // Only touches the interval.
+			continue
+		}
+		if hasEnd && sl.compare(k.Start, interval.Start) < 0 {
+			pieces = append(pieces, IntervalKey[T, V]{Start: k.Start, End: end, Key: k.Key})
+		}
+		if hasStart && sl.compare(k.End, interval.End) > 0 {
+			pieces = append(pieces, IntervalKey[T, V]{Start: start, End: k.End, Key: k.Key})
+		}
+		affected = append(affected, k)
+	}
+	for _, k := range pieces {
+		if sl.Get(k) == nil {
+			sl.insert(k)
+		}
+	}
+	sl.check()
+	return affected
+}
+
+// insert adds a new key to the list, or updates the key of an existing interval.
+// Returns the previous key and true if the interval exists.
+func (sl *SkipList[T, V]) insert(intervalKey IntervalKey[T, V]) (IntervalKey[T, V], bool) {
+	var n *Node[T, V]
 	var i int
-	nodePath := make([]*Node, MaxLevel) // Top-to-bottom path to the inserted node.
-	dist := make([]int, MaxLevel)       // Tracks the cumulative distance (span) traveled at each level.
+	var nodePath [MaxLevel]*Node[T, V] // Top-to-bottom path to the inserted node.
+	var dist [MaxLevel]int             // Tracks the cumulative distance (span) traveled at each level.
 
 	// Find the position to insert the new node, top level down search.
 	n = sl.head
@@ -88,19 +319,27 @@ func (sl *SkipList) Insert(intervalKey IntervalKey) *IntervalKey {
 			dist[i] = dist[i+1] // Initialize with travelled distance from the level above.
 		}
 		// Positions n at the last node whose interval does not exceed the new interval's start.
-		for n.levels[i].next != nil && less(n.levels[i].next.intervalKey, intervalKey) {
+		for n.levels[i].next != nil && less(sl.compare, n.levels[i].next.intervalKey, intervalKey) {
 			dist[i] += n.levels[i].span // Accumulate span traversed.
 			n = n.levels[i].next
 		}
 		nodePath[i] = n // Populate for each level.
 	}
 
-	if n.levels[0].next != nil && n.levels[0].next.intervalKey.equalInterval(intervalKey) {
+	return sl.insertAt(&nodePath, &dist, intervalKey)
+}
+
+// insertAt adds a new key after the path, or updates the key of the node that follows it.
+// The path holds the last node before the key at each level of the list, and dist their positions
+// (the head is at position 0). The path is kept up to date, ending before the inserted node.
+func (sl *SkipList[T, V]) insertAt(nodePath *[MaxLevel]*Node[T, V], dist *[MaxLevel]int, intervalKey IntervalKey[T, V]) (IntervalKey[T, V], bool) {
+	n := nodePath[0]
+	if n.levels[0].next != nil && n.levels[0].next.intervalKey.equalInterval(intervalKey, sl.compare) {
 		// Interval exists. Update the node's key.
 		xn := n.levels[0].next
 		xk := xn.intervalKey
 		xn.intervalKey = intervalKey
-		return &xk
+		return xk, true
 	}
 
 	// Create a new node for the new key and link it.
@@ -109,7 +348,7 @@ func (sl *SkipList) Insert(intervalKey IntervalKey) *IntervalKey {
 	for i, insertMaxLevel := 0, max(sl.maxLevel, rLevel); i < insertMaxLevel; i++ {
 		if i >= sl.maxLevel {
 			// Initialize any new higher levels.
-			nodePath[i] = sl.head
+			nodePath[i], dist[i] = sl.head, 0
 			nodePath[i].levels[i].span = sl.length
 			sl.maxLevel++
 		}
@@ -124,108 +363,446 @@ func (sl *SkipList) Insert(intervalKey IntervalKey) *IntervalKey {
 			nodePath[i].levels[i].span++
 		}
 	}
+	// Link the backward pointer at the base level.
+	if nodePath[0] != sl.head {
+		n.prev = nodePath[0]
+	}
+	if n.levels[0].next != nil {
+		n.levels[0].next.prev = n
+	} else {
+		sl.tail = n
+	}
+	// Refresh the max End of the affected pointers, bottom-up as each level depends on the one below.
+	for i := 0; i < sl.maxLevel; i++ {
+		if i < rLevel {
+			n.updateMaxEnd(i, sl.compare)
+		}
+		nodePath[i].updateMaxEnd(i, sl.compare)
+	}
 	sl.length++
-	return nil
+	return IntervalKey[T, V]{}, false
 }
 
 // Delete removes a key with the specified interval.
 // Returns the key of the deleted node if found.
-func (sl *SkipList) Delete(interval IntervalKey) *IntervalKey {
-	var k IntervalKey
-	var n *Node
+func (sl *SkipList[T, V]) Delete(interval IntervalKey[T, V]) *IntervalKey[T, V] {
+	k, ok := sl.DeleteKey(interval)
+	if !ok {
+		return nil
+	}
+	deleted := k
+	return &deleted
+}
+
+// DeleteKey removes a key with the specified interval, see Delete.
+// Returns the key of the deleted node and true if found. Unlike Delete, it doesn't allocate.
+func (sl *SkipList[T, V]) DeleteKey(interval IntervalKey[T, V]) (IntervalKey[T, V], bool) {
+	var n *Node[T, V]
 	var i int
-	nodePath := make([]*Node, MaxLevel)
+	var nodePath [MaxLevel]*Node[T, V]
 
 	// Find the node to delete.
 	n = sl.head
 	for i = sl.maxLevel - 1; i >= 0; i-- {
-		for n.levels[i].next != nil && less(n.levels[i].next.intervalKey, interval) {
+		for n.levels[i].next != nil && less(sl.compare, n.levels[i].next.intervalKey, interval) {
 			n = n.levels[i].next
 		}
 		nodePath[i] = n
 	}
 	n = n.levels[0].next
-	if n == nil || !n.intervalKey.equalInterval(interval) {
-		return nil
+	if n == nil || !n.intervalKey.equalInterval(interval, sl.compare) {
+		return IntervalKey[T, V]{}, false
 	}
+	k := sl.deleteAt(&nodePath, n)
+	sl.check()
+	return k, true
+}
 
-	// Delete/Unlink the node.
+// deleteAt unlinks the node that follows the path, and returns its key.
+// The path holds the last node before the node at each level of the list.
+func (sl *SkipList[T, V]) deleteAt(nodePath *[MaxLevel]*Node[T, V], n *Node[T, V]) IntervalKey[T, V] {
 	ml := sl.maxLevel
 	for i := 0; i < ml; i++ {
 		// Levels where the node exists.
 		if i < len(n.levels) && nodePath[i].levels[i].next == n {
 			nodePath[i].levels[i].next = n.levels[i].next
 			nodePath[i].levels[i].span += n.levels[i].span - 1
-			if (sl.maxLevel > i && sl.maxLevel > 1) && sl.head.levels[i].next == nil {
-				sl.maxLevel = i // Adjust maxLevel to the highest level that contain nodes.
-			}
 		} else {
 			// Levels beyond the node's levels.
 			nodePath[i].levels[i].span--
 		}
 	}
-	k = n.intervalKey
+	for i := 0; i < ml; i++ {
+		nodePath[i].updateMaxEnd(i, sl.compare)
+	}
+	// Adjust maxLevel to the highest level that contain nodes.
+	for sl.maxLevel > 1 && sl.head.levels[sl.maxLevel-1].next == nil {
+		sl.maxLevel--
+	}
+	if n.levels[0].next != nil {
+		n.levels[0].next.prev = n.prev
+	} else {
+		sl.tail = n.prev
+	}
+	k := n.intervalKey
 	sl.pool.put(n)
 	sl.length--
-	return &k
-}
-
-// Overlaps returns all keys that overlap the query interval.
-func (sl *SkipList) Overlaps(interval IntervalKey, qParam QueryParam) (result []*IntervalKey) {
-	defer func() {
-		if r := recover(); r != nil {
-			fmt.Println()
-			sl.Print(os.Stdout, MaxLevel)
-			// fmt.Printf("\nlevel: %d", sl.maxLevel)
-			fmt.Println()
-			panic(r)
-		}
-	}()
+	return k
+}
 
-	// Find the start node to begin overlap check from,
-	// i.e. the largest node with an interval less than the query interval.
-	n := sl.head
-	for i := sl.maxSearchLevel(); i >= 0; i-- {
-		for n.levels[i].next != nil && less(n.levels[i].next.intervalKey, interval) {
+// DeleteOverlapping removes all keys that overlap the query interval.
+// Returns the deleted keys in list order.
+//
+// The nodes are unlinked in a single pass over the overlapping run of the list, rather than
+// searching the list for each key.
+func (sl *SkipList[T, V]) DeleteOverlapping(interval IntervalKey[T, V]) []IntervalKey[T, V] {
+	keys := sl.deleteOverlapping(interval, sl.bounds == Closed)
+	sl.check()
+	return keys
+}
+
+// deleteOverlapping removes all keys that overlap the query interval, including keys that only
+// share a bound with it if inclusive.
+func (sl *SkipList[T, V]) deleteOverlapping(interval IntervalKey[T, V], inclusive bool) []IntervalKey[T, V] {
+	first, _ := sl.nextOverlap(sl.head, 0, interval.Start, inclusive)
+	if first == nil || !sl.before(first.intervalKey.Start, interval.End, inclusive) {
+		return nil
+	}
+	var path [MaxLevel]*Node[T, V]
+	var pos [MaxLevel]int
+	sl.findPath(func(ik IntervalKey[T, V]) bool { return less(sl.compare, ik, first.intervalKey) }, &path, &pos)
+	return sl.deleteRun(&path, &pos,
+		func(n *Node[T, V]) bool { return !sl.before(n.intervalKey.Start, interval.End, inclusive) },
+		func(n *Node[T, V]) bool { return sl.before(interval.Start, n.intervalKey.End, inclusive) },
+	)
+}
+
+// DeleteRange removes the keys at the 0-based index positions in [startIndex, endIndex).
+// Returns the deleted keys in list order.
+func (sl *SkipList[T, V]) DeleteRange(startIndex, endIndex int) ([]IntervalKey[T, V], error) {
+	if startIndex < 0 || endIndex > sl.length || startIndex > endIndex {
+		return nil, fmt.Errorf("index range out of bounds: [%d,%d)", startIndex, endIndex)
+	}
+	if startIndex == endIndex {
+		return nil, nil
+	}
+	var path [MaxLevel]*Node[T, V]
+	var pos [MaxLevel]int
+	n, _ := sl.GetByIndex(startIndex)
+	first := *n
+	sl.findPath(func(ik IntervalKey[T, V]) bool { return less(sl.compare, ik, first) }, &path, &pos)
+	count := endIndex - startIndex
+	keys := sl.deleteRun(&path, &pos,
+		func(*Node[T, V]) bool { count--; return count < 0 },
+		func(*Node[T, V]) bool { return true },
+	)
+	sl.check()
+	return keys, nil
+}
+
+// findPath populates the last node whose key satisfies before at each level of the list, and its
+// position (the head is at position 0). The keys satisfying before must form a prefix of the list.
+func (sl *SkipList[T, V]) findPath(before func(ik IntervalKey[T, V]) bool, path *[MaxLevel]*Node[T, V], pos *[MaxLevel]int) {
+	n, p := sl.head, 0
+	for i := sl.maxLevel - 1; i >= 0; i-- {
+		for n.levels[i].next != nil && before(n.levels[i].next.intervalKey) {
+			p += n.levels[i].span
 			n = n.levels[i].next
 		}
+		path[i], pos[i] = n, p
 	}
+}
+
+// deleteRun unlinks, in a single pass, the nodes for which remove returns true from the run of
+// nodes that follows the path, up to the first node for which stop returns true.
+// The path holds the last node before the run at each level, and pos their positions.
+// Returns the keys of the removed nodes in list order.
+func (sl *SkipList[T, V]) deleteRun(path *[MaxLevel]*Node[T, V], pos *[MaxLevel]int, stop, remove func(n *Node[T, V]) bool) []IntervalKey[T, V] {
+	var keys []IntervalKey[T, V]
+	var removed *Node[T, V]      // Removed nodes, chained through prev.
+	preds, newPos := *path, *pos // Last kept node at each level, and its position after removal.
+	last, lastPos := *path, *pos // Last node passed at each level, and its position before removal.
+	count := 0
 
-	// Find overlapping nodes (a < qEnd) && (b > qStart).
-	for count := 0; n != nil && n.intervalKey.Start <= interval.End; {
-		if n.intervalKey.End >= interval.Start {
-			if count >= qParam.Offset {
-				result = append(result, &n.intervalKey)
-				if qParam.Limit != 0 && len(result) >= qParam.Limit {
-					break
-				}
+	p := pos[0] + 1
+	for n := path[0].levels[0].next; n != nil && !stop(n); p++ {
+		next := n.levels[0].next
+		if remove(n) {
+			for i := range n.levels {
+				last[i], lastPos[i] = n, p
 			}
+			keys = append(keys, n.intervalKey)
+			n.prev = removed
+			removed = n
 			count++
+		} else {
+			// Link the kept node to the last kept node at each of its levels.
+			if preds[0] != sl.head {
+				n.prev = preds[0]
+			} else {
+				n.prev = nil
+			}
+			for i := range n.levels {
+				preds[i].levels[i].next = n
+				preds[i].levels[i].span = p - count - newPos[i]
+				preds[i], newPos[i] = n, p-count
+				last[i], lastPos[i] = n, p
+			}
+		}
+		n = next
+	}
+	if count == 0 {
+		return nil
+	}
+
+	// Link the last kept node at each level to the node that followed the run.
+	for i := 0; i < sl.maxLevel; i++ {
+		succPos := lastPos[i] + last[i].levels[i].span // The span of a nil pointer reaches the list end.
+		preds[i].levels[i].next = last[i].levels[i].next
+		preds[i].levels[i].span = succPos - count - newPos[i]
+	}
+	if succ := preds[0].levels[0].next; succ != nil {
+		succ.prev = preds[0]
+		if preds[0] == sl.head {
+			succ.prev = nil
 		}
-		n = n.levels[0].next
+	} else if preds[0] != sl.head {
+		sl.tail = preds[0]
+	} else {
+		sl.tail = nil
+	}
+	// Refresh the max End of the pointers from the path to the end of the run, bottom-up.
+	for i := 0; i < sl.maxLevel; i++ {
+		for n := path[i]; ; n = n.levels[i].next {
+			n.updateMaxEnd(i, sl.compare)
+			if n == preds[i] {
+				break
+			}
+		}
+	}
+	for sl.maxLevel > 1 && sl.head.levels[sl.maxLevel-1].next == nil {
+		sl.maxLevel--
+	}
+	sl.length -= count
+	for removed != nil {
+		prev := removed.prev
+		sl.pool.put(removed)
+		removed = prev
+	}
+	return keys
+}
+
+// Overlaps returns all keys that overlap the query interval, in list order.
+// Intervals in the list may overlap or nest within each other.
+func (sl *SkipList[T, V]) Overlaps(interval IntervalKey[T, V], qParam QueryParam) (result []*IntervalKey[T, V]) {
+	// Find overlapping nodes (a <= qEnd) && (b >= qStart), or (a < qEnd) && (b > qStart) unless the bounds are closed.
+	// Nodes are ordered by Start, so the scan ends at the first node starting after the query.
+	inclusive := sl.bounds == Closed // Whether intervals that share a bound overlap.
+	n, pos := sl.nextOverlap(sl.head, 0, interval.Start, inclusive)
+	for count := 0; n != nil && sl.before(n.intervalKey.Start, interval.End, inclusive); n, pos = sl.nextOverlap(n, pos, interval.Start, inclusive) {
+		if count >= qParam.Offset {
+			result = append(result, &n.intervalKey)
+			if qParam.Limit != 0 && len(result) >= qParam.Limit {
+				break
+			}
+		}
+		count++
 	}
 	return result
 }
 
+// OverlapsPage returns up to limit keys that overlap the query interval, in list order, starting
+// after the position of the token, and a token for the next page. The token is not Valid after the
+// last page. A limit of 0 returns all remaining keys.
+//
+// Each page resumes in O(log n) from the token, rather than rescanning the prior overlaps as
+// QueryParam.Offset does. Keys inserted or deleted between pages before the token are not revisited.
+func (sl *SkipList[T, V]) OverlapsPage(interval IntervalKey[T, V], limit int, token PageToken[T]) ([]*IntervalKey[T, V], PageToken[T]) {
+	var result []*IntervalKey[T, V]
+	inclusive := sl.bounds == Closed
+	n, pos := sl.head, 0
+	if token.Valid {
+		after := IntervalKey[T, V]{Start: token.Start, End: token.End}
+		n, pos = sl.seek(func(ik IntervalKey[T, V]) bool { return !less(sl.compare, after, ik) })
+	}
+	for n, pos = sl.nextOverlap(n, pos, interval.Start, inclusive); n != nil && sl.before(n.intervalKey.Start, interval.End, inclusive); n, pos = sl.nextOverlap(n, pos, interval.Start, inclusive) {
+		if limit > 0 && len(result) == limit {
+			last := result[len(result)-1]
+			return result, PageToken[T]{Start: last.Start, End: last.End, Valid: true}
+		}
+		result = append(result, &n.intervalKey)
+	}
+	return result, PageToken[T]{}
+}
+
+// OverlapsBatch calls fn with each key that overlaps each of the query intervals, where qi is the
+// index of the query in queries. The queries are answered in order of their Start, and the keys of
+// each query in list order.
+//
+// The queries are answered in a single forward sweep of the list. The first overlap of a query is
+// never before the first overlap of a query that starts before it, so each search resumes from the
+// previous one and costs O(log d), where d is the distance between them, rather than O(log n).
+// The list must not be modified by fn.
+func (sl *SkipList[T, V]) OverlapsBatch(queries []IntervalKey[T, V], fn func(qi int, ik IntervalKey[T, V])) {
+	order := make([]int, len(queries))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int { return sl.compare(queries[a].Start, queries[b].Start) })
+
+	inclusive := sl.bounds == Closed
+	finger, pos := sl.head, 0 // The node before the first overlap of the previous query.
+	for _, qi := range order {
+		q := queries[qi]
+		n, p := sl.nextOverlap(finger, pos, q.Start, inclusive)
+		if n == nil {
+			return // No node reaches the start of this query, nor of the queries after it.
+		}
+		if finger, pos = n.prev, p-1; finger == nil {
+			finger = sl.head
+		}
+		for ; n != nil && sl.before(n.intervalKey.Start, q.End, inclusive); n, p = sl.nextOverlap(n, p, q.Start, inclusive) {
+			fn(qi, n.intervalKey)
+		}
+	}
+}
+
+// StabFirst returns the first key, in list order, whose interval contains the point.
+// Whether a point on a bound is contained depends on the list Bounds.
+func (sl *SkipList[T, V]) StabFirst(point T) (IntervalKey[T, V], bool) {
+	n, _ := sl.nextOverlap(sl.head, 0, point, sl.bounds.includesEnd())
+	if n == nil || !sl.before(n.intervalKey.Start, point, sl.bounds.includesStart()) {
+		return IntervalKey[T, V]{}, false
+	}
+	return n.intervalKey, true
+}
+
+// Covers reports whether any interval in the list contains the point.
+func (sl *SkipList[T, V]) Covers(point T) bool {
+	_, ok := sl.StabFirst(point)
+	return ok
+}
+
+// nextOverlap returns the first node after n whose interval ends after start, or at start if
+// inclusive, and its position in the list given the position of n (the head is at position 0).
+// Returns nil if there is no such node.
+//
+// Runs of nodes that all end before start are skipped by climbing the levels of the
+// nodes passed, using the max End maintained for each forward pointer. This keeps the
+// search sub-linear when long intervals are nested over, or overlap, their successors.
+func (sl *SkipList[T, V]) nextOverlap(n *Node[T, V], pos int, start T, inclusive bool) (*Node[T, V], int) {
+	i := 0
+	for {
+		next := n.levels[i].next
+		if next == nil || sl.before(start, n.levels[i].maxEnd, inclusive) {
+			if i == 0 {
+				return next, pos + 1
+			}
+			// The node is somewhere in (n, next], or beyond next if it is nil.
+			i--
+			continue
+		}
+		// No node in (n, next] reaches start. Climb as high as the node allows and skip.
+		for i+1 < len(n.levels) && n.levels[i+1].next != nil && !sl.before(start, n.levels[i+1].maxEnd, inclusive) {
+			i++
+		}
+		pos += n.levels[i].span
+		n = n.levels[i].next
+	}
+}
+
+// before reports whether a < b, or a <= b if inclusive.
+func (sl *SkipList[T, V]) before(a, b T, inclusive bool) bool {
+	if inclusive {
+		return sl.compare(a, b) <= 0
+	}
+	return sl.compare(a, b) < 0
+}
+
 // Get retrieves a key by its interval.
-// Returns nil if the interval doesn't exist.
-func (sl *SkipList) Get(interval IntervalKey) *IntervalKey {
+// Returns nil if the interval doesn't exist. The bounds are matched exactly, whatever the list Bounds.
+func (sl *SkipList[T, V]) Get(interval IntervalKey[T, V]) *IntervalKey[T, V] {
 	n := sl.head
 	for i := sl.maxSearchLevel(); i >= 0; i-- {
-		for n.levels[i].next != nil && less(n.levels[i].next.intervalKey, interval) {
+		for n.levels[i].next != nil && less(sl.compare, n.levels[i].next.intervalKey, interval) {
 			n = n.levels[i].next
 		}
 	}
 	n = n.levels[0].next
-	if n != nil && n.intervalKey.equalInterval(interval) {
+	if n != nil && n.intervalKey.equalInterval(interval, sl.compare) {
 		return &n.intervalKey
 	}
 	return nil
 }
 
+// Floor returns the last key whose interval starts at or before the point.
+func (sl *SkipList[T, V]) Floor(point T) (IntervalKey[T, V], bool) {
+	n, _ := sl.seek(func(ik IntervalKey[T, V]) bool { return sl.compare(ik.Start, point) <= 0 })
+	return sl.keyOf(n)
+}
+
+// Ceiling returns the first key whose interval starts at or after the point.
+func (sl *SkipList[T, V]) Ceiling(point T) (IntervalKey[T, V], bool) {
+	n, _ := sl.seek(func(ik IntervalKey[T, V]) bool { return sl.compare(ik.Start, point) < 0 })
+	return sl.keyOf(n.levels[0].next)
+}
+
+// Prev returns the key that precedes the interval in the list.
+// The interval does not need to exist in the list.
+func (sl *SkipList[T, V]) Prev(interval IntervalKey[T, V]) (IntervalKey[T, V], bool) {
+	n, _ := sl.seek(func(ik IntervalKey[T, V]) bool { return less(sl.compare, ik, interval) })
+	return sl.keyOf(n)
+}
+
+// Next returns the key that succeeds the interval in the list.
+// The interval does not need to exist in the list.
+func (sl *SkipList[T, V]) Next(interval IntervalKey[T, V]) (IntervalKey[T, V], bool) {
+	n, _ := sl.seek(func(ik IntervalKey[T, V]) bool { return !less(sl.compare, interval, ik) })
+	return sl.keyOf(n.levels[0].next)
+}
+
+// Rank returns the 0-based index position of the interval in the list, see GetByIndex.
+// Returns false if the interval doesn't exist.
+func (sl *SkipList[T, V]) Rank(interval IntervalKey[T, V]) (int, bool) {
+	n, pos := sl.seek(func(ik IntervalKey[T, V]) bool { return less(sl.compare, ik, interval) })
+	if n = n.levels[0].next; n != nil && n.intervalKey.equalInterval(interval, sl.compare) {
+		return pos, true
+	}
+	return 0, false
+}
+
+// RankOf returns the number of keys whose interval starts before the point.
+// This is the 0-based index position of Ceiling(point), or the list length if there is none.
+func (sl *SkipList[T, V]) RankOf(point T) int {
+	_, pos := sl.seek(func(ik IntervalKey[T, V]) bool { return sl.compare(ik.Start, point) < 0 })
+	return pos
+}
+
+// seek returns the last node whose key satisfies before, or the head if there is none,
+// and its position in the list (the head is at position 0).
+// The keys satisfying before must form a prefix of the list.
+func (sl *SkipList[T, V]) seek(before func(ik IntervalKey[T, V]) bool) (*Node[T, V], int) {
+	n, pos := sl.head, 0
+	for i := sl.maxSearchLevel(); i >= 0; i-- {
+		for n.levels[i].next != nil && before(n.levels[i].next.intervalKey) {
+			pos += n.levels[i].span
+			n = n.levels[i].next
+		}
+	}
+	return n, pos
+}
+
+// keyOf returns the key of the node, or false if the node is nil or the head.
+func (sl *SkipList[T, V]) keyOf(n *Node[T, V]) (IntervalKey[T, V], bool) {
+	if n == nil || n == sl.head {
+		return IntervalKey[T, V]{}, false
+	}
+	return n.intervalKey, true
+}
+
 // GetByIndex retrieves a key by its index position in the list.
 // The index is 0-based (sl.length < index >= 0 ).
-func (sl *SkipList) GetByIndex(index int) (*IntervalKey, error) {
+func (sl *SkipList[T, V]) GetByIndex(index int) (*IntervalKey[T, V], error) {
 	if index < 0 || index >= sl.length {
 		return nil, fmt.Errorf("index out of bounds: %d", index)
 	}
@@ -248,7 +825,7 @@ func (sl *SkipList) GetByIndex(index int) (*IntervalKey, error) {
 }
 
 // Print outputs a visual representation of the list from the given startLevel to the base level.
-func (sl *SkipList) Print(w io.Writer, startLevel int) {
+func (sl *SkipList[T, V]) Print(w io.Writer, startLevel int) {
 	if sl == nil {
 		return
 	}
@@ -263,7 +840,7 @@ func (sl *SkipList) Print(w io.Writer, startLevel int) {
 		fmt.Fprintf(w, "Level %d: ", lvl)
 		current := sl.head
 		for current != nil {
-			fmt.Fprintf(w, "{i: [%d,%d], k: %s, s: %d} -> ",
+			fmt.Fprintf(w, "{i: [%v,%v], k: %v, s: %d} -> ",
 				current.intervalKey.Start,
 				current.intervalKey.End,
 				current.intervalKey.Key,
@@ -276,13 +853,35 @@ func (sl *SkipList) Print(w io.Writer, startLevel int) {
 	}
 }
 
+// free returns all nodes of the list, including the head, to the pool.
+// The list must not be used afterwards.
+func (sl *SkipList[T, V]) free() {
+	for n := sl.head; n != nil; {
+		next := n.levels[0].next
+		sl.pool.put(n)
+		n = next
+	}
+	sl.head = nil
+	sl.tail = nil
+}
+
 // maxSearchLevel returns the effective maximum search limit for level traversal.
-// This optimizes performance in large lists by restricting traversal
-// to the most relevant lower levels.
-func (sl *SkipList) maxSearchLevel() int {
+// This optimizes performance in large lists by skipping any sparse levels above the
+// levels expected for the list length, see searchLevels.
+func (sl *SkipList[T, V]) maxSearchLevel() int {
 	maxSearchLevel := sl.maxLevel - 1
-	if maxSearchLevel >= MaxSearchLevel {
-		maxSearchLevel = MaxSearchLevel - 1
+	if limit := sl.searchLevels(); maxSearchLevel >= limit {
+		maxSearchLevel = limit - 1
 	}
 	return maxSearchLevel
 }
+
+// searchLevels returns the number of levels searched by lookups. Unless capped WithSearchLevelCap,
+// it is one more than the expected number of levels of the list, log_(1/p)(n), so lookups stay
+// O(log n) as the list grows.
+func (sl *SkipList[T, V]) searchLevels() int {
+	if sl.searchCap > 0 {
+		return sl.searchCap
+	}
+	return int(math.Ceil(float64(bits.Len(uint(sl.length)))*sl.levelScale)) + 1
+}
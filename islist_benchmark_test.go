@@ -3,6 +3,8 @@ package islist
 import (
 	"fmt"
 	"math/rand"
+	randv2 "math/rand/v2"
+	"runtime"
 	"slices"
 	"testing"
 )
@@ -22,11 +24,11 @@ const (
 	oneYear    = 365 * oneDay // 525600 minutes
 )
 
-var randIntervals = make([]IntervalKey, 0, numIntervals)
+var randIntervals = make([]IntervalKey[int, string], 0, numIntervals)
 
 // init initializes the benchmark tests.
 func init() {
-	var start int64
+	var start int
 	for start = 0; start < iRange; start += iSpan + 1 {
 		randIntervals = append(randIntervals, NewIntervalKey(start, start+iSpan, "key"))
 	}
@@ -35,15 +37,15 @@ func init() {
 	})
 }
 
-func newRandomInterval(bound int64, length int64) IntervalKey {
-	start := rand.Int63n(bound)
-	end := start + rand.Int63n(length) + 1
+func newRandomInterval(bound int, length int) IntervalKey[int, string] {
+	start := rand.Intn(bound)
+	end := start + rand.Intn(length) + 1
 	return NewIntervalKey(start, end, "key")
 }
 
-func newPopulatedTestList() (*SkipList, []IntervalKey) {
+func newPopulatedTestList() (*SkipList[int, string], []IntervalKey[int, string]) {
 	list := newTestList()
-	intervals := make([]IntervalKey, numIntervals)
+	intervals := make([]IntervalKey[int, string], numIntervals)
 	copy(intervals, randIntervals)
 	for _, i := range intervals {
 		list.Insert(i)
@@ -65,9 +67,9 @@ func chooseOperation(ops map[string]float64) string {
 }
 
 // 1 year = 525600 minutes. 10-minute interval slots = 52560 intervals.
-func generateYearIntervals10Min() []IntervalKey {
-	is := make([]IntervalKey, 0, oneYear/tenMinutes)
-	var start int64
+func generateYearIntervals10Min() []IntervalKey[int, string] {
+	is := make([]IntervalKey[int, string], 0, oneYear/tenMinutes)
+	var start int
 	for start = 0; start < oneYear; start += tenMinutes {
 		end := start + tenMinutes
 		is = append(is, NewIntervalKey(start, end, "key"))
@@ -79,8 +81,8 @@ func BenchmarkISListAscendingInsert(b *testing.B) {
 	list := newTestList()
 	b.ReportAllocs()
 	b.ResetTimer()
-	var i int64
-	for i = 0; i < int64(b.N); i += 11 {
+	var i int
+	for i = 0; i < b.N; i += 11 {
 		list.Insert(NewIntervalKey(i, i+10, "key"))
 	}
 }
@@ -89,15 +91,15 @@ func BenchmarkISListDescendingInsert(b *testing.B) {
 	list := newTestList()
 	b.ReportAllocs()
 	b.ResetTimer()
-	var i int64
-	for i = int64(b.N * 11); i >= 0; i -= 11 {
+	var i int
+	for i = b.N * 11; i >= 0; i -= 11 {
 		list.Insert(NewIntervalKey(i, i+10, "key"))
 	}
 }
 
 func BenchmarkISListRandomInsert(b *testing.B) {
 	list := newTestList()
-	intervals := make([]IntervalKey, numIntervals)
+	intervals := make([]IntervalKey[int, string], numIntervals)
 	copy(intervals, randIntervals)
 	b.ReportAllocs()
 	b.ResetTimer()
@@ -117,7 +119,7 @@ func BenchmarkISListDelete(b *testing.B) {
 
 func BenchmarkISListOverlaps(b *testing.B) {
 	list, _ := newPopulatedTestList()
-	querySpans := []int64{10, 100, 1000}
+	querySpans := []int{10, 100, 1000}
 	for _, span := range querySpans {
 		b.Run(fmt.Sprintf("Query Span=%d", span), func(b *testing.B) {
 			b.ReportAllocs()
@@ -129,6 +131,46 @@ func BenchmarkISListOverlaps(b *testing.B) {
 	}
 }
 
+// Benchmark InsertKey and DeleteKey in the steady state, which don't allocate.
+func BenchmarkISListInsertDelete(b *testing.B) {
+	list, _ := newPopulatedTestList()
+	r := randv2.New(randv2.NewPCG(1, 2))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		start := r.IntN(iRange)
+		ik := NewIntervalKey(start, start+1, "key")
+		list.InsertKey(ik)
+		list.DeleteKey(ik)
+	}
+}
+
+// Benchmark answering a batch of queries, one per chart bucket of a dashboard,
+// in a single sweep versus one search per query.
+func BenchmarkISListOverlapsBatch(b *testing.B) {
+	list, _ := newPopulatedTestList()
+	queries := make([]IntervalKey[int, string], 200)
+	b.Run("Overlaps", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j := range queries {
+				start := iRange/2 + j*100 // Adjacent buckets.
+				_ = list.Overlaps(NewIntervalQuery[int, string](start, start+100), QueryParam{})
+			}
+		}
+	})
+	b.Run("OverlapsBatch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j := range queries {
+				start := iRange/2 + j*100 // Adjacent buckets.
+				queries[j] = NewIntervalQuery[int, string](start, start+100)
+			}
+			list.OverlapsBatch(queries, func(int, IntervalKey[int, string]) {})
+		}
+	})
+}
+
 // Benchmark mixed operations throughput.
 func BenchmarkISListMixedOperations(b *testing.B) {
 	ops := map[string]float64{
@@ -136,11 +178,11 @@ func BenchmarkISListMixedOperations(b *testing.B) {
 		"delete":   0.1,
 		"overlaps": 0.7,
 	}
-	ranges := []int64{200_000, 2_000_000}
+	ranges := []int{200_000, 2_000_000}
 	for _, r := range ranges {
 		b.Run(fmt.Sprintf("Range=%d", r), func(b *testing.B) {
 			list := newTestList()
-			addedIntervals := make([]IntervalKey, 0, b.N)
+			addedIntervals := make([]IntervalKey[int, string], 0, b.N)
 
 			b.ReportAllocs()
 			b.ResetTimer()
@@ -171,15 +213,15 @@ func BenchmarkISListTimeSpanOverlapQueries(b *testing.B) {
 	for _, iv := range generateYearIntervals10Min() {
 		list.Insert(iv)
 	}
-	generateQuery := func(maxLen int64) IntervalKey {
-		start := rand.Int63n(oneYear)
-		length := rand.Int63n(maxLen)
+	generateQuery := func(maxLen int) IntervalKey[int, string] {
+		start := rand.Intn(oneYear)
+		length := rand.Intn(maxLen)
 		end := start + length + 1
 		return NewIntervalKey(start, end, "key")
 	}
 	timeSpans := []struct {
 		name string
-		span int64
+		span int
 	}{
 		{"10min", tenMinutes},
 		{"1h", oneHour},
@@ -198,3 +240,235 @@ func BenchmarkISListTimeSpanOverlapQueries(b *testing.B) {
 		})
 	}
 }
+
+func newPopulatedConcurrentTestList() *ConcurrentSkipList[int, string] {
+	list := newTestConcurrentList()
+	for _, i := range randIntervals {
+		list.Insert(i)
+	}
+	return list
+}
+
+func BenchmarkConcurrentISListParallelOverlaps(b *testing.B) {
+	list := newPopulatedConcurrentTestList()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			_ = list.Overlaps(newRandomInterval(numIntervals, 100), QueryParam{})
+		}
+	})
+}
+
+// Benchmark parallel mixed operations throughput.
+func BenchmarkConcurrentISListParallelMixedOperations(b *testing.B) {
+	ops := map[string]float64{
+		"insert":   0.2,
+		"delete":   0.1,
+		"overlaps": 0.7,
+	}
+	list := newPopulatedConcurrentTestList()
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			interval := newRandomInterval(iRange, iSpan)
+			switch chooseOperation(ops) {
+			case "insert":
+				list.Insert(interval)
+			case "delete":
+				list.Delete(randIntervals[rand.Intn(len(randIntervals))])
+			case "overlaps":
+				_ = list.Overlaps(interval, QueryParam{})
+			}
+		}
+	})
+}
+
+func BenchmarkISListNewFromSorted(b *testing.B) {
+	intervals := slices.Clone(randIntervals)
+	slices.SortFunc(intervals, func(a, b IntervalKey[int, string]) int { return a.Start - b.Start })
+	pool := NewNodePool[int, string]()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewFromSorted(pool, randv2.NewPCG(2, 3), intervals); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkISListStab(b *testing.B) {
+	list, _ := newPopulatedTestList()
+	b.Run("Overlaps", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			p := rand.Intn(iRange)
+			_ = list.Overlaps(NewIntervalQuery[int, string](p, p), QueryParam{})
+		}
+	})
+	b.Run("StabFirst", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = list.StabFirst(rand.Intn(iRange))
+		}
+	})
+}
+
+// newLargeTestList returns a list of n contiguous intervals, built without searching the list.
+func newLargeTestList(n int, opts ...Option) *SkipList[int, struct{}] {
+	list := New(NewNodePool[int, struct{}](), randv2.NewPCG(2, 3), opts...)
+	b := newBuilder(list)
+	for i := 0; i < n; i++ {
+		b.append(newNode(list.pool, list.randomLevel(), NewIntervalKey(i*2, i*2+1, struct{}{})))
+	}
+	b.finish()
+	return list
+}
+
+// Benchmark lookups in large lists. The time per lookup should grow with log(n),
+// unless the search levels are capped.
+func BenchmarkISListLargeLookups(b *testing.B) {
+	for _, n := range []int{1_000_000, 10_000_000, 100_000_000} {
+		if testing.Short() && n > 1_000_000 {
+			continue
+		}
+		for _, name := range []string{"Adaptive", "Capped"} {
+			b.Run(fmt.Sprintf("%s/N=%d", name, n), func(b *testing.B) {
+				var list *SkipList[int, struct{}]
+				if name == "Capped" {
+					list = newLargeTestList(n, WithSearchLevelCap(MaxSearchLevel))
+				} else {
+					list = newLargeTestList(n)
+				}
+				defer list.free()
+				b.Run("Get", func(b *testing.B) {
+					b.ReportAllocs()
+					b.ReportMetric(float64(list.maxSearchLevel()+1), "levels")
+					for i := 0; i < b.N; i++ {
+						p := rand.Intn(n) * 2
+						_ = list.Get(NewIntervalQuery[int, struct{}](p, p+1))
+					}
+				})
+				b.Run("GetByIndex", func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						_, _ = list.GetByIndex(rand.Intn(n))
+					}
+				})
+				b.Run("Overlaps", func(b *testing.B) {
+					b.ReportAllocs()
+					for i := 0; i < b.N; i++ {
+						p := rand.Intn(n) * 2
+						_ = list.Overlaps(NewIntervalQuery[int, struct{}](p, p+10), QueryParam{})
+					}
+				})
+			})
+		}
+	}
+}
+
+// Benchmark node allocators and layouts, by insert and lookup time, and by the time of a full GC
+// with a populated list.
+func BenchmarkNodeAllocators(b *testing.B) {
+	for _, alloc := range []struct {
+		name string
+		new  func() NodeAllocator[int, string]
+	}{
+		{"Pool", func() NodeAllocator[int, string] { return NewNodePool[int, string]() }},
+		{"Arena", func() NodeAllocator[int, string] { return NewNodeArena[int, string](0) }},
+		{"Compact", func() NodeAllocator[int, string] { return NewCompactNodePool[int, string]() }},
+	} {
+		b.Run(alloc.name+"/Insert", func(b *testing.B) {
+			list := New(alloc.new(), randv2.NewPCG(2, 3))
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				list.Insert(NewIntervalKey(i*2, i*2+1, "key"))
+			}
+		})
+		b.Run(alloc.name+"/Get", func(b *testing.B) {
+			list := New(alloc.new(), randv2.NewPCG(2, 3))
+			for _, ik := range randIntervals {
+				list.Insert(ik)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = list.Get(randIntervals[i%len(randIntervals)])
+			}
+		})
+		b.Run(alloc.name+"/Overlaps", func(b *testing.B) {
+			list := New(alloc.new(), randv2.NewPCG(2, 3))
+			for _, ik := range randIntervals {
+				list.Insert(ik)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_ = list.Overlaps(newRandomInterval(iRange, 100), QueryParam{})
+			}
+		})
+		b.Run(alloc.name+"/GC", func(b *testing.B) {
+			list := New(alloc.new(), randv2.NewPCG(2, 3))
+			for i := 0; i < 1_000_000; i++ {
+				list.Insert(NewIntervalKey(i*2, i*2+1, "key"))
+			}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				runtime.GC()
+			}
+			runtime.KeepAlive(list)
+		})
+	}
+}
+
+// Benchmark lookups of keys in ascending order, a step apart, by searching from the head versus
+// from a cursor. The cursor saves the descent from the top levels, though with nodes inserted in
+// random order most of the time is spent on cache misses at the lower levels.
+func BenchmarkCursorSeek(b *testing.B) {
+	list, _ := newPopulatedTestList()
+	keys := slices.Collect(list.All())
+	for _, step := range []int{1, 10, 100} {
+		b.Run(fmt.Sprintf("Step=%d/Get", step), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = list.Get(keys[i*step%len(keys)])
+			}
+		})
+		b.Run(fmt.Sprintf("Step=%d/Cursor", step), func(b *testing.B) {
+			c := list.Cursor()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = c.Seek(keys[i*step%len(keys)])
+			}
+		})
+	}
+}
+
+// Benchmark paging through all overlaps of a query, by offset versus by token.
+func BenchmarkISListOverlapsPaging(b *testing.B) {
+	list, _ := newPopulatedTestList()
+	q := NewIntervalQuery[int, string](0, iRange/10)
+	const limit = 100
+	b.Run("Offset", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for offset := 0; ; offset += limit {
+				if len(list.Overlaps(q, QueryParam{Offset: offset, Limit: limit})) < limit {
+					break
+				}
+			}
+		}
+	})
+	b.Run("Token", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var token PageToken[int]
+			for {
+				if _, token = list.OverlapsPage(q, limit, token); !token.Valid {
+					break
+				}
+			}
+		}
+	})
+}
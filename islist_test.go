@@ -1,27 +1,32 @@
 package islist
 
 import (
+	"cmp"
+	"errors"
+	"math"
 	"math/rand/v2"
+	"slices"
 	"testing"
+	"time"
 )
 
 // TODO: Add no-overlap test.
 
 // Helper function to create a test tree.
-func newTestList() *SkipList {
-	return New(NewNodePool(), rand.NewPCG(2, 3))
+func newTestList() *SkipList[int, string] {
+	return New(NewNodePool[int, string](), rand.NewPCG(2, 3))
 }
 
 type expectedNode struct {
-	intervalKey IntervalKey
+	intervalKey IntervalKey[int, string]
 	span        int
 }
 
-func assertNodeEqual(t *testing.T, level int, n *Node, expected expectedNode) {
+func assertNodeEqual(t *testing.T, level int, n *Node[int, string], expected expectedNode) {
 	if n == nil {
 		t.Fatalf("expected node but got nil")
 	}
-	if !n.intervalKey.equalInterval(expected.intervalKey) {
+	if !n.intervalKey.equalInterval(expected.intervalKey, cmp.Compare[int]) {
 		t.Errorf("node interval mismatch. got %s, expected %s", n.intervalKey, expected.intervalKey)
 	}
 	if n.intervalKey.Key != expected.intervalKey.Key {
@@ -32,9 +37,9 @@ func assertNodeEqual(t *testing.T, level int, n *Node, expected expectedNode) {
 	}
 }
 
-func assertNodesEqual(t *testing.T, sl *SkipList, expectedNodes []expectedNode) {
+func assertNodesEqual(t *testing.T, sl *SkipList[int, string], expectedNodes []expectedNode) {
 	t.Helper()
-	var n *Node
+	var n *Node[int, string]
 	for i, j := sl.maxLevel-1, 0; i >= 0; i-- {
 		n = sl.head
 		for n != nil {
@@ -50,7 +55,7 @@ type expectedList struct {
 	length int
 }
 
-func assertListEqual(t *testing.T, sl *SkipList, expected expectedList) {
+func assertListEqual(t *testing.T, sl *SkipList[int, string], expected expectedList) {
 	t.Helper()
 	if sl.maxLevel != expected.level {
 		t.Errorf("list level mismatch. got %d, expected %d", sl.maxLevel, expected.level)
@@ -110,49 +115,49 @@ func TestOverlapQuery(t *testing.T) {
 	list.Insert(NewIntervalKey(90, 100, "test-6"))
 
 	t.Run("Overlap query end before list minStart", func(t *testing.T) {
-		r := list.Overlaps(NewIntervalQuery(1, 2), QueryParam{})
+		r := list.Overlaps(NewIntervalQuery[int, string](1, 2), QueryParam{})
 		if len(r) != 0 {
 			t.Errorf("expected no overlapping intervals. got %d", len(r))
 		}
 	})
 
 	t.Run("Overlap query end after list maxEnd", func(t *testing.T) {
-		r := list.Overlaps(NewIntervalQuery(101, 102), QueryParam{})
+		r := list.Overlaps(NewIntervalQuery[int, string](101, 102), QueryParam{})
 		if len(r) != 0 {
 			t.Errorf("expected no overlapping intervals. got %d", len(r))
 		}
 	})
 
 	t.Run("Overlap query start before list minStart", func(t *testing.T) {
-		r := list.Overlaps(NewIntervalQuery(1, 14), QueryParam{})
+		r := list.Overlaps(NewIntervalQuery[int, string](1, 14), QueryParam{})
 		if len(r) != 2 {
 			t.Errorf("expected 2 overlapping intervals. got %d", len(r))
 		}
 	})
 
 	t.Run("Overlap query end after list maxEnd", func(t *testing.T) {
-		r := list.Overlaps(NewIntervalQuery(75, 105), QueryParam{})
+		r := list.Overlaps(NewIntervalQuery[int, string](75, 105), QueryParam{})
 		if len(r) != 2 {
 			t.Errorf("expected 2 overlapping intervals. got %d", len(r))
 		}
 	})
 
 	t.Run("Overlap query start before list minStart and end after maxEnd", func(t *testing.T) {
-		r := list.Overlaps(NewIntervalQuery(1, 105), QueryParam{})
+		r := list.Overlaps(NewIntervalQuery[int, string](1, 105), QueryParam{})
 		if len(r) != list.length {
 			t.Errorf("expected %d overlapping intervals. got %d", list.length, len(r))
 		}
 	})
 
 	t.Run("Overlap query between two intervals", func(t *testing.T) {
-		r := list.Overlaps(NewIntervalQuery(21, 25), QueryParam{})
+		r := list.Overlaps(NewIntervalQuery[int, string](21, 25), QueryParam{})
 		if len(r) != 0 {
 			t.Errorf("expected 0 overlapping intervals. got %d", len(r))
 		}
 	})
 
 	t.Run("Overlap query inclusive start and inclusive end", func(t *testing.T) {
-		r := list.Overlaps(NewIntervalQuery(40, 50), QueryParam{})
+		r := list.Overlaps(NewIntervalQuery[int, string](40, 50), QueryParam{})
 		if len(r) != 2 {
 			t.Errorf("expected 2 overlapping intervals. got %d", len(r))
 		}
@@ -199,7 +204,7 @@ func TestDelete(t *testing.T) {
 	t.Run("Delete non-existing interval in empty list", func(t *testing.T) {
 		list := newTestList()
 		assertListEqual(t, list, expectedList{level: 1, length: 0})
-		k := list.Delete(NewIntervalQuery(1, 2))
+		k := list.Delete(NewIntervalQuery[int, string](1, 2))
 		assertListEqual(t, list, expectedList{level: 1, length: 0})
 		if k != nil {
 			t.Errorf("expected nil returned after delete")
@@ -220,3 +225,826 @@ func TestDelete(t *testing.T) {
 		}
 	})
 }
+
+func TestOverlapQueryNestedIntervals(t *testing.T) {
+	list := newTestList()
+	list.Insert(NewIntervalKey(0, 1000, "outer"))
+	list.Insert(NewIntervalKey(100, 200, "inner-1"))
+	list.Insert(NewIntervalKey(150, 600, "inner-2"))
+	list.Insert(NewIntervalKey(300, 310, "inner-3"))
+	list.Insert(NewIntervalKey(700, 800, "inner-4"))
+
+	t.Run("Overlap query inside long interval", func(t *testing.T) {
+		r := list.Overlaps(NewIntervalQuery[int, string](500, 510), QueryParam{})
+		if len(r) != 2 {
+			t.Fatalf("expected 2 overlapping intervals. got %d", len(r))
+		}
+		if r[0].Key != "outer" || r[1].Key != "inner-2" {
+			t.Errorf("expected [outer inner-2]. got [%s %s]", r[0].Key, r[1].Key)
+		}
+	})
+
+	t.Run("Overlap query after deleting long interval", func(t *testing.T) {
+		list.Delete(NewIntervalQuery[int, string](0, 1000))
+		r := list.Overlaps(NewIntervalQuery[int, string](500, 510), QueryParam{})
+		if len(r) != 1 || r[0].Key != "inner-2" {
+			t.Errorf("expected [inner-2]. got %v", r)
+		}
+	})
+}
+
+func TestOverlapQueryRandomIntervals(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	list := newTestList()
+	var inserted []IntervalKey[int, string]
+	for i := 0; i < 2000; i++ {
+		start := r.IntN(10_000)
+		ik := NewIntervalKey(start, start+r.IntN(500), "key")
+		if r.IntN(4) == 0 && len(inserted) > 0 {
+			j := r.IntN(len(inserted))
+			list.Delete(inserted[j])
+			inserted = append(inserted[:j], inserted[j+1:]...)
+			continue
+		}
+		if list.Insert(ik) == nil {
+			inserted = append(inserted, ik)
+		}
+	}
+	for i := 0; i < 200; i++ {
+		start := r.IntN(10_000)
+		q := NewIntervalQuery[int, string](start, start+r.IntN(100))
+		expected := 0
+		for _, ik := range inserted {
+			if ik.Start <= q.End && ik.End >= q.Start {
+				expected++
+			}
+		}
+		if got := len(list.Overlaps(q, QueryParam{})); got != expected {
+			t.Fatalf("query %s: expected %d overlapping intervals. got %d", q, expected, got)
+		}
+	}
+}
+
+func TestOverlapsBatch(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+	for _, bounds := range []Bounds{Closed, HalfOpen} {
+		list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithBounds(bounds))
+		for i := 0; i < 2000; i++ {
+			start := r.IntN(10_000)
+			list.Insert(NewIntervalKey(start, start+1+r.IntN(500), "key"))
+		}
+		queries := make([]IntervalKey[int, string], 300)
+		for i := range queries {
+			start := r.IntN(11_000)
+			queries[i] = NewIntervalQuery[int, string](start, start+r.IntN(100))
+		}
+		got := make([][]IntervalKey[int, string], len(queries))
+		list.OverlapsBatch(queries, func(qi int, ik IntervalKey[int, string]) {
+			got[qi] = append(got[qi], ik)
+		})
+		for qi, q := range queries {
+			var expected []IntervalKey[int, string]
+			for _, ik := range list.Overlaps(q, QueryParam{}) {
+				expected = append(expected, *ik)
+			}
+			if !slices.Equal(got[qi], expected) {
+				t.Fatalf("%s: query %s: expected %v. got %v", bounds, q, expected, got[qi])
+			}
+		}
+	}
+}
+
+func TestValuePayloads(t *testing.T) {
+	type booking struct {
+		id    int
+		owner string
+	}
+	list := New(NewNodePool[int, *booking](), rand.NewPCG(2, 3))
+	b1 := &booking{id: 1, owner: "alice"}
+	b2 := &booking{id: 2, owner: "bob"}
+	list.Insert(NewIntervalKey(0, 10, b1))
+	list.Insert(NewIntervalKey(20, 30, b2))
+
+	if k := list.Get(NewIntervalQuery[int, *booking](20, 30)); k == nil || k.Key != b2 {
+		t.Errorf("expected key %v. got %v", b2, k)
+	}
+	r := list.Overlaps(NewIntervalQuery[int, *booking](5, 25), QueryParam{})
+	if len(r) != 2 || r[0].Key != b1 || r[1].Key != b2 {
+		t.Errorf("expected keys [%v %v]. got %v", b1, b2, r)
+	}
+	if k := list.Delete(NewIntervalQuery[int, *booking](0, 10)); k == nil || k.Key != b1 {
+		t.Errorf("expected deleted key %v. got %v", b1, k)
+	}
+}
+
+func TestBoundTypes(t *testing.T) {
+	t.Run("Float bounds with negative values", func(t *testing.T) {
+		list := New(NewNodePool[float64, string](), rand.NewPCG(2, 3))
+		list.Insert(NewIntervalKey(-10.5, -2.5, "cold"))
+		list.Insert(NewIntervalKey(-2.5, 15.0, "mild"))
+		list.Insert(NewIntervalKey(15.0, 40.0, "hot"))
+		r := list.Overlaps(NewIntervalQuery[float64, string](-5, 0), QueryParam{})
+		if len(r) != 2 || r[0].Key != "cold" || r[1].Key != "mild" {
+			t.Errorf("expected [cold mild]. got %v", r)
+		}
+	})
+
+	t.Run("Time bounds with compare function", func(t *testing.T) {
+		t0 := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+		window := func(from, to time.Duration, key string) IntervalKey[time.Time, string] {
+			return IntervalKey[time.Time, string]{Start: t0.Add(from), End: t0.Add(to), Key: key}
+		}
+		list := NewFunc(NewNodePool[time.Time, string](), rand.NewPCG(2, 3), time.Time.Compare)
+		list.Insert(window(0, 8*time.Hour, "shift"))
+		list.Insert(window(time.Hour, 2*time.Hour, "meeting"))
+		list.Insert(window(3*time.Hour, 4*time.Hour, "lunch"))
+		r := list.Overlaps(window(90*time.Minute, 200*time.Minute, ""), QueryParam{})
+		if len(r) != 3 {
+			t.Fatalf("expected 3 overlapping intervals. got %d", len(r))
+		}
+		if k := list.Get(window(time.Hour, 2*time.Hour, "")); k == nil || k.Key != "meeting" {
+			t.Errorf("expected meeting. got %v", k)
+		}
+	})
+}
+
+func TestFloorAndCeiling(t *testing.T) {
+	list := newTestList()
+	list.Insert(NewIntervalKey(5, 9, "test-1"))
+	list.Insert(NewIntervalKey(10, 20, "test-2"))
+	list.Insert(NewIntervalKey(10, 25, "test-3"))
+	list.Insert(NewIntervalKey(30, 40, "test-4"))
+
+	tests := []struct {
+		point   int
+		floor   string
+		ceiling string
+	}{
+		{point: 0, floor: "", ceiling: "test-1"},
+		{point: 5, floor: "test-1", ceiling: "test-1"},
+		{point: 7, floor: "test-1", ceiling: "test-2"},
+		{point: 10, floor: "test-3", ceiling: "test-2"},
+		{point: 29, floor: "test-3", ceiling: "test-4"},
+		{point: 35, floor: "test-4", ceiling: ""},
+	}
+	for _, test := range tests {
+		if k, ok := list.Floor(test.point); k.Key != test.floor || ok != (test.floor != "") {
+			t.Errorf("Floor(%d): expected %q. got %q, %t", test.point, test.floor, k.Key, ok)
+		}
+		if k, ok := list.Ceiling(test.point); k.Key != test.ceiling || ok != (test.ceiling != "") {
+			t.Errorf("Ceiling(%d): expected %q. got %q, %t", test.point, test.ceiling, k.Key, ok)
+		}
+	}
+}
+
+func TestPrevAndNext(t *testing.T) {
+	list := newTestList()
+	list.Insert(NewIntervalKey(5, 9, "test-1"))
+	list.Insert(NewIntervalKey(10, 20, "test-2"))
+	list.Insert(NewIntervalKey(30, 40, "test-3"))
+
+	tests := []struct {
+		interval IntervalKey[int, string]
+		prev     string
+		next     string
+	}{
+		{interval: NewIntervalQuery[int, string](5, 9), prev: "", next: "test-2"},
+		{interval: NewIntervalQuery[int, string](10, 20), prev: "test-1", next: "test-3"},
+		{interval: NewIntervalQuery[int, string](10, 15), prev: "test-1", next: "test-2"},
+		{interval: NewIntervalQuery[int, string](30, 40), prev: "test-2", next: ""},
+		{interval: NewIntervalQuery[int, string](50, 60), prev: "test-3", next: ""},
+	}
+	for _, test := range tests {
+		if k, ok := list.Prev(test.interval); k.Key != test.prev || ok != (test.prev != "") {
+			t.Errorf("Prev(%s): expected %q. got %q, %t", test.interval, test.prev, k.Key, ok)
+		}
+		if k, ok := list.Next(test.interval); k.Key != test.next || ok != (test.next != "") {
+			t.Errorf("Next(%s): expected %q. got %q, %t", test.interval, test.next, k.Key, ok)
+		}
+	}
+}
+
+func TestRank(t *testing.T) {
+	list := newTestList()
+	for i := 0; i < 100; i++ {
+		list.Insert(NewIntervalKey(i*10, i*10+5, "key"))
+	}
+
+	t.Run("Rank of existing intervals", func(t *testing.T) {
+		for i := 0; i < 100; i++ {
+			ik := NewIntervalQuery[int, string](i*10, i*10+5)
+			rank, ok := list.Rank(ik)
+			if !ok || rank != i {
+				t.Fatalf("Rank(%s): expected %d. got %d, %t", ik, i, rank, ok)
+			}
+			if k, _ := list.GetByIndex(rank); !k.equalInterval(ik, list.compare) {
+				t.Fatalf("GetByIndex(%d): expected %s. got %s", rank, ik, k)
+			}
+		}
+	})
+
+	t.Run("Rank of non-existing interval", func(t *testing.T) {
+		if _, ok := list.Rank(NewIntervalQuery[int, string](10, 16)); ok {
+			t.Errorf("expected interval to not exist")
+		}
+	})
+
+	t.Run("Rank of point", func(t *testing.T) {
+		tests := []struct{ point, expected int }{{-1, 0}, {0, 0}, {1, 1}, {10, 1}, {11, 2}, {995, 100}, {2000, 100}}
+		for _, test := range tests {
+			if got := list.RankOf(test.point); got != test.expected {
+				t.Errorf("RankOf(%d): expected %d. got %d", test.point, test.expected, got)
+			}
+		}
+	})
+}
+
+func TestDeleteOverlapping(t *testing.T) {
+	t.Run("Delete contiguous overlapping intervals", func(t *testing.T) {
+		list := newTestList()
+		for i := 0; i < 10; i++ {
+			list.Insert(NewIntervalKey(i*10, i*10+5, "key"))
+		}
+		deleted := list.DeleteOverlapping(NewIntervalQuery[int, string](26, 52))
+		if len(deleted) != 3 || deleted[0].Start != 30 || deleted[2].Start != 50 {
+			t.Errorf("expected [30 40 50] deleted. got %v", deleted)
+		}
+		assertListEqual(t, list, expectedList{level: list.maxLevel, length: 7})
+		assertIndexable(t, list)
+		if r := list.Overlaps(NewIntervalQuery[int, string](26, 52), QueryParam{}); len(r) != 0 {
+			t.Errorf("expected no overlapping intervals. got %v", r)
+		}
+	})
+
+	t.Run("Delete nested overlapping intervals", func(t *testing.T) {
+		list := newTestList()
+		list.Insert(NewIntervalKey(0, 1000, "outer"))
+		list.Insert(NewIntervalKey(100, 200, "inner-1"))
+		list.Insert(NewIntervalKey(150, 160, "inner-2"))
+		list.Insert(NewIntervalKey(300, 310, "inner-3"))
+		deleted := list.DeleteOverlapping(NewIntervalQuery[int, string](180, 305))
+		if got := keysOf(deleted); !slices.Equal(got, []string{"outer", "inner-1", "inner-3"}) {
+			t.Errorf("expected [outer inner-1 inner-3] deleted. got %v", got)
+		}
+		if got := keysOf(slices.Collect(list.Backward())); !slices.Equal(got, []string{"inner-2"}) {
+			t.Errorf("expected [inner-2] remaining. got %v", got)
+		}
+		assertIndexable(t, list)
+	})
+
+	t.Run("Delete all intervals", func(t *testing.T) {
+		list := newTestList()
+		for i := 0; i < 100; i++ {
+			list.Insert(NewIntervalKey(i*10, i*10+5, "key"))
+		}
+		if deleted := list.DeleteOverlapping(NewIntervalQuery[int, string](0, 1000)); len(deleted) != 100 {
+			t.Errorf("expected 100 deleted. got %d", len(deleted))
+		}
+		assertListEqual(t, list, expectedList{level: 1, length: 0})
+		list.Insert(NewIntervalKey(1, 2, "key"))
+		assertIndexable(t, list)
+	})
+
+	t.Run("Delete random overlapping intervals", func(t *testing.T) {
+		r := rand.New(rand.NewPCG(1, 2))
+		list := newTestList()
+		for i := 0; i < 1000; i++ {
+			start := r.IntN(10_000)
+			list.Insert(NewIntervalKey(start, start+r.IntN(200), "key"))
+		}
+		for i := 0; i < 50; i++ {
+			start := r.IntN(10_000)
+			q := NewIntervalQuery[int, string](start, start+r.IntN(100))
+			expected := 0
+			for ik := range list.All() {
+				if ik.Start <= q.End && ik.End >= q.Start {
+					expected++
+				}
+			}
+			if got := len(list.Overlaps(q, QueryParam{})); got != expected {
+				t.Fatalf("query %s: expected %d overlapping intervals. got %d", q, expected, got)
+			}
+			length := list.length
+			if got := len(list.DeleteOverlapping(q)); got != expected {
+				t.Fatalf("query %s: expected %d deleted. got %d", q, expected, got)
+			}
+			if list.length != length-expected {
+				t.Fatalf("list length mismatch. got %d, expected %d", list.length, length-expected)
+			}
+			if r := list.Overlaps(q, QueryParam{}); len(r) != 0 {
+				t.Fatalf("query %s: expected no overlapping intervals. got %d", q, len(r))
+			}
+			assertIndexable(t, list)
+		}
+	})
+}
+
+func TestDeleteRange(t *testing.T) {
+	newList := func() *SkipList[int, string] {
+		list := newTestList()
+		for i := 0; i < 100; i++ {
+			list.Insert(NewIntervalKey(i*10, i*10+5, "key"))
+		}
+		return list
+	}
+
+	t.Run("Delete index range", func(t *testing.T) {
+		list := newList()
+		deleted, err := list.DeleteRange(10, 20)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(deleted) != 10 || deleted[0].Start != 100 || deleted[9].Start != 190 {
+			t.Errorf("expected [100..190] deleted. got %v", deleted)
+		}
+		assertListEqual(t, list, expectedList{level: list.maxLevel, length: 90})
+		assertIndexable(t, list)
+		if k, _ := list.GetByIndex(10); k.Start != 200 {
+			t.Errorf("expected [200,205] at index 10. got %s", k)
+		}
+	})
+
+	t.Run("Delete head and tail ranges", func(t *testing.T) {
+		list := newList()
+		list.DeleteRange(0, 5)
+		list.DeleteRange(list.length-5, list.length)
+		assertListEqual(t, list, expectedList{level: list.maxLevel, length: 90})
+		assertIndexable(t, list)
+		if k, _ := list.Floor(1000); k.Start != 940 {
+			t.Errorf("expected last interval [940,945]. got %s", k)
+		}
+		if k := slices.Collect(list.Backward()); k[0].Start != 940 || k[len(k)-1].Start != 50 {
+			t.Errorf("expected backward from 940 to 50. got %s to %s", k[0], k[len(k)-1])
+		}
+	})
+
+	t.Run("Delete out of bounds range", func(t *testing.T) {
+		list := newList()
+		for _, r := range [][2]int{{-1, 5}, {5, 101}, {6, 5}} {
+			if _, err := list.DeleteRange(r[0], r[1]); err == nil {
+				t.Errorf("expected error for range [%d,%d)", r[0], r[1])
+			}
+		}
+		assertListEqual(t, list, expectedList{level: list.maxLevel, length: 100})
+	})
+}
+
+func TestStab(t *testing.T) {
+	list := newTestList()
+	list.Insert(NewIntervalKey(0, 1000, "outer"))
+	list.Insert(NewIntervalKey(10, 20, "test-1"))
+	list.Insert(NewIntervalKey(20, 30, "test-2"))
+	list.Insert(NewIntervalKey(50, 60, "test-3"))
+	list.Insert(NewIntervalKey(2000, 3000, "test-4"))
+
+	tests := []struct {
+		point    int
+		expected []string
+	}{
+		{point: -1, expected: nil},
+		{point: 20, expected: []string{"outer", "test-1", "test-2"}},
+		{point: 40, expected: []string{"outer"}},
+		{point: 1500, expected: nil},
+		{point: 3000, expected: []string{"test-4"}},
+	}
+	for _, test := range tests {
+		if got := keysOf(slices.Collect(list.Stab(test.point))); !slices.Equal(got, test.expected) {
+			t.Errorf("Stab(%d): expected %v. got %v", test.point, test.expected, got)
+		}
+		first, ok := list.StabFirst(test.point)
+		if ok != (len(test.expected) > 0) || (ok && first.Key != test.expected[0]) {
+			t.Errorf("StabFirst(%d): expected %v. got %s, %t", test.point, test.expected, first, ok)
+		}
+		if covers := list.Covers(test.point); covers != (len(test.expected) > 0) {
+			t.Errorf("Covers(%d): expected %t. got %t", test.point, !covers, covers)
+		}
+	}
+}
+
+func TestBoundsSemantics(t *testing.T) {
+	tests := []struct {
+		bounds   Bounds
+		point    int
+		stab     []string
+		query    [2]int
+		overlaps []string
+	}{
+		{bounds: Closed, point: 10, stab: []string{"a", "b"}, query: [2]int{20, 25}, overlaps: []string{"b"}},
+		{bounds: HalfOpen, point: 10, stab: []string{"b"}, query: [2]int{20, 25}, overlaps: nil},
+		{bounds: HalfOpen, point: 0, stab: []string{"a"}, query: [2]int{5, 10}, overlaps: []string{"a"}},
+		{bounds: LeftOpen, point: 10, stab: []string{"a"}, query: [2]int{0, 5}, overlaps: []string{"a"}},
+		{bounds: LeftOpen, point: 0, stab: nil, query: [2]int{19, 20}, overlaps: []string{"b"}},
+		{bounds: Open, point: 10, stab: nil, query: [2]int{10, 15}, overlaps: []string{"b"}},
+		{bounds: Open, point: 5, stab: []string{"a"}, query: [2]int{-5, 0}, overlaps: nil},
+	}
+	for _, test := range tests {
+		list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithBounds(test.bounds))
+		list.Insert(NewIntervalKey(0, 10, "a"))
+		list.Insert(NewIntervalKey(10, 20, "b"))
+
+		if got := keysOf(slices.Collect(list.Stab(test.point))); !slices.Equal(got, test.stab) {
+			t.Errorf("%s Stab(%d): expected %v. got %v", test.bounds, test.point, test.stab, got)
+		}
+		if covers := list.Covers(test.point); covers != (len(test.stab) > 0) {
+			t.Errorf("%s Covers(%d): expected %t. got %t", test.bounds, test.point, !covers, covers)
+		}
+		query := NewIntervalQuery[int, string](test.query[0], test.query[1])
+		var got []string
+		for _, k := range list.Overlaps(query, QueryParam{}) {
+			got = append(got, k.Key)
+		}
+		if !slices.Equal(got, test.overlaps) {
+			t.Errorf("%s Overlaps(%s): expected %v. got %v", test.bounds, query, test.overlaps, got)
+		}
+		var seq []string
+		for _, k := range list.OverlapsSeq(query) {
+			seq = append(seq, k.Key)
+		}
+		if !slices.Equal(seq, test.overlaps) {
+			t.Errorf("%s OverlapsSeq(%s): expected %v. got %v", test.bounds, query, test.overlaps, seq)
+		}
+	}
+
+	t.Run("Back-to-back exclusive inserts", func(t *testing.T) {
+		list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithBounds(HalfOpen))
+		list.Insert(NewIntervalKey(0, 10, "a"))
+		if _, err := list.InsertExclusive(NewIntervalKey(10, 20, "b")); err != nil {
+			t.Errorf("expected [10,20) to be disjoint from [0,10). got %v", err)
+		}
+		if _, err := list.InsertExclusive(NewIntervalKey(19, 30, "c")); !errors.Is(err, ErrOverlap) {
+			t.Errorf("expected ErrOverlap for [19,30). got %v", err)
+		}
+		if removed := list.DeleteOverlapping(NewIntervalQuery[int, string](20, 30)); len(removed) != 0 {
+			t.Errorf("expected no intervals removed. got %v", removed)
+		}
+	})
+
+	t.Run("Coalesce touching intervals", func(t *testing.T) {
+		concat := func(a, b string) string { return a + "+" + b }
+		for _, b := range []Bounds{Closed, HalfOpen, LeftOpen, Open} {
+			list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithBounds(b), WithCoalesce(concat))
+			list.Insert(NewIntervalKey(0, 10, "a"))
+			list.Insert(NewIntervalKey(10, 20, "b"))
+			expected := 1
+			if b == Open {
+				expected = 2 // (0,10) and (10,20) do not share the point 10.
+			}
+			if list.length != expected {
+				t.Errorf("%s: expected %d intervals. got %v", b, expected, slices.Collect(list.All()))
+			}
+		}
+	})
+}
+
+func TestStabAllocs(t *testing.T) {
+	list := newTestList()
+	for i := 0; i < 1000; i++ {
+		list.Insert(NewIntervalKey(i*10, i*10+5, "key"))
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = list.Covers(5005)
+		_, _ = list.StabFirst(5005)
+		for range list.Stab(5005) {
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations. got %.1f", allocs)
+	}
+}
+
+func TestInsertDeleteAllocs(t *testing.T) {
+	list := newTestList()
+	for i := 0; i < 1000; i++ {
+		list.Insert(NewIntervalKey(i*10, i*10+5, "key"))
+	}
+	r := rand.New(rand.NewPCG(1, 2))
+	// Warm up the pool, so nodes with enough levels are reused.
+	for i := 0; i < 1000; i++ {
+		start := r.IntN(10000)
+		ik := NewIntervalKey(start, start+1, "key")
+		list.InsertKey(ik)
+		list.DeleteKey(ik)
+	}
+	allocs := testing.AllocsPerRun(1000, func() {
+		start := r.IntN(10000)
+		ik := NewIntervalKey(start, start+1, "key")
+		list.InsertKey(ik)
+		list.InsertKey(ik) // Update.
+		list.DeleteKey(ik)
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations. got %.1f", allocs)
+	}
+}
+
+func TestInsertDeleteReturnCopies(t *testing.T) {
+	list := newTestList()
+	list.Insert(NewIntervalKey(0, 10, "a"))
+	list.Insert(NewIntervalKey(20, 30, "b"))
+	u1 := list.Insert(NewIntervalKey(0, 10, "c"))
+	u2 := list.Insert(NewIntervalKey(20, 30, "d"))
+	d1 := list.Delete(NewIntervalQuery[int, string](0, 10))
+	d2 := list.Delete(NewIntervalQuery[int, string](20, 30))
+	if u1.Key != "a" || u2.Key != "b" || d1.Key != "c" || d2.Key != "d" {
+		t.Errorf("expected keys a, b, c, d. got %s, %s, %s, %s", u1.Key, u2.Key, d1.Key, d2.Key)
+	}
+	if k, ok := list.DeleteKey(NewIntervalQuery[int, string](0, 10)); ok {
+		t.Errorf("expected no key. got %s", k)
+	}
+}
+
+func TestCoalesce(t *testing.T) {
+	concat := func(a, b string) string { return a + "+" + b }
+	newList := func() *SkipList[int, string] {
+		return New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithCoalesce(concat))
+	}
+
+	t.Run("Merge overlapping interval", func(t *testing.T) {
+		list := newList()
+		list.Insert(NewIntervalKey(0, 10, "a"))
+		k := list.Insert(NewIntervalKey(5, 15, "b"))
+		if k == nil || k.Key != "a" {
+			t.Errorf("expected merged key a returned. got %v", k)
+		}
+		got := slices.Collect(list.All())
+		if len(got) != 1 || got[0] != NewIntervalKey(0, 15, "a+b") {
+			t.Errorf("expected [[0,15] a+b]. got %v", got)
+		}
+	})
+
+	t.Run("Merge touching and spanned intervals", func(t *testing.T) {
+		list := newList()
+		list.Insert(NewIntervalKey(0, 10, "a"))
+		list.Insert(NewIntervalKey(20, 30, "b"))
+		list.Insert(NewIntervalKey(40, 50, "c"))
+		list.Insert(NewIntervalKey(60, 70, "d"))
+		list.Insert(NewIntervalKey(10, 45, "e"))
+		got := slices.Collect(list.All())
+		expected := []IntervalKey[int, string]{NewIntervalKey(0, 50, "a+b+c+e"), NewIntervalKey(60, 70, "d")}
+		if !slices.Equal(got, expected) {
+			t.Errorf("expected %v. got %v", expected, got)
+		}
+		assertIndexable(t, list)
+	})
+
+	t.Run("Insert disjoint interval", func(t *testing.T) {
+		list := newList()
+		list.Insert(NewIntervalKey(0, 10, "a"))
+		if k := list.Insert(NewIntervalKey(11, 15, "b")); k != nil {
+			t.Errorf("expected nil returned. got %s", k)
+		}
+		assertListEqual(t, list, expectedList{level: list.maxLevel, length: 2})
+	})
+
+	t.Run("Mismatched merge function", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("expected panic for mismatched merge function")
+			}
+		}()
+		New(NewNodePool[int, int](), rand.NewPCG(2, 3), WithCoalesce(concat))
+	})
+}
+
+func TestSubtract(t *testing.T) {
+	t.Run("Split and trim intervals", func(t *testing.T) {
+		list := newTestList()
+		list.Insert(NewIntervalKey(0, 10, "a"))
+		list.Insert(NewIntervalKey(15, 20, "b"))
+		list.Insert(NewIntervalKey(22, 40, "c"))
+		list.Insert(NewIntervalKey(40, 50, "d"))
+		affected := list.Subtract(NewIntervalQuery[int, string](5, 25))
+		if got := keysOf(affected); !slices.Equal(got, []string{"a", "b", "c"}) {
+			t.Errorf("expected [a b c] affected. got %v", got)
+		}
+		got := slices.Collect(list.All())
+		expected := []IntervalKey[int, string]{NewIntervalKey(0, 4, "a"), NewIntervalKey(26, 40, "c"), NewIntervalKey(40, 50, "d")}
+		if !slices.Equal(got, expected) {
+			t.Errorf("expected %v. got %v", expected, got)
+		}
+	})
+
+	t.Run("Split interval in two", func(t *testing.T) {
+		list := newTestList()
+		list.Insert(NewIntervalKey(0, 100, "a"))
+		list.Subtract(NewIntervalQuery[int, string](40, 60))
+		got := slices.Collect(list.All())
+		expected := []IntervalKey[int, string]{NewIntervalKey(0, 39, "a"), NewIntervalKey(61, 100, "a")}
+		if !slices.Equal(got, expected) {
+			t.Errorf("expected %v. got %v", expected, got)
+		}
+		assertIndexable(t, list)
+	})
+
+	t.Run("Pieces exclude the interval", func(t *testing.T) {
+		tests := []struct {
+			bounds   Bounds
+			expected [][2]int
+		}{
+			{bounds: Closed, expected: [][2]int{{0, 4}, {11, 20}}},
+			{bounds: HalfOpen, expected: [][2]int{{0, 5}, {10, 20}}},
+			{bounds: LeftOpen, expected: [][2]int{{0, 5}, {10, 20}}},
+			{bounds: Open, expected: [][2]int{{0, 5}, {10, 20}}},
+		}
+		for _, test := range tests {
+			list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithBounds(test.bounds))
+			list.Insert(NewIntervalKey(0, 20, "a"))
+			q := NewIntervalQuery[int, string](5, 10)
+			list.Subtract(q)
+			if got := bounds(slices.Collect(list.All())); !slices.Equal(got, test.expected) {
+				t.Errorf("%s: expected %v. got %v", test.bounds, test.expected, got)
+			}
+			for p := 4; p <= 11; p++ {
+				subtracted := (p > 5 || p == 5 && test.bounds.includesStart()) && (p < 10 || p == 10 && test.bounds.includesEnd())
+				if covered := list.Covers(p); subtracted && covered || (p < 5 || p > 10) && !covered {
+					t.Errorf("%s: expected %d covered %t. got %t", test.bounds, p, !subtracted, covered)
+				}
+			}
+			if got := list.Overlaps(q, QueryParam{}); len(got) != 0 {
+				t.Errorf("%s: expected no overlaps of %s. got %v", test.bounds, q, got)
+			}
+		}
+	})
+
+	t.Run("Float pieces", func(t *testing.T) {
+		list := New(NewNodePool[float64, string](), rand.NewPCG(2, 3))
+		list.Insert(NewIntervalKey(0.0, 20.0, "a"))
+		list.Subtract(NewIntervalQuery[float64, string](5, 10))
+		got := slices.Collect(list.All())
+		if len(got) != 2 || got[0].End != math.Nextafter(5, 0) || got[1].Start != math.Nextafter(10, 20) {
+			t.Errorf("expected pieces next to 5 and 10. got %v", got)
+		}
+	})
+
+	t.Run("Touching intervals", func(t *testing.T) {
+		for _, b := range []Bounds{Closed, HalfOpen} {
+			list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithBounds(b))
+			list.Insert(NewIntervalKey(0, 10, "a"))
+			list.Insert(NewIntervalKey(20, 30, "b"))
+			affected := list.Subtract(NewIntervalQuery[int, string](10, 20))
+			if b == HalfOpen {
+				if len(affected) != 0 {
+					t.Errorf("%s: expected no affected intervals. got %v", b, affected)
+				}
+				assertListEqual(t, list, expectedList{level: list.maxLevel, length: 2})
+				continue
+			}
+			// In Closed lists the intervals share a bound with the interval.
+			if got := keysOf(affected); !slices.Equal(got, []string{"a", "b"}) {
+				t.Errorf("%s: expected [a b] affected. got %v", b, got)
+			}
+			if got, expected := bounds(slices.Collect(list.All())), [][2]int{{0, 9}, {21, 30}}; !slices.Equal(got, expected) {
+				t.Errorf("%s: expected %v. got %v", b, expected, got)
+			}
+		}
+	})
+
+	t.Run("Touching intervals of other types are unchanged", func(t *testing.T) {
+		list := NewFunc(NewNodePool[int, string](), rand.NewPCG(2, 3), cmp.Compare[int])
+		list.Insert(NewIntervalKey(0, 10, "a"))
+		list.Insert(NewIntervalKey(20, 40, "b"))
+		if affected := list.Subtract(NewIntervalQuery[int, string](10, 30)); len(affected) != 1 {
+			t.Errorf("expected 1 affected interval. got %v", affected)
+		}
+		if got, expected := bounds(slices.Collect(list.All())), [][2]int{{0, 10}, {30, 40}}; !slices.Equal(got, expected) {
+			t.Errorf("expected %v. got %v", expected, got)
+		}
+	})
+
+	t.Run("Pieces don't overwrite keys", func(t *testing.T) {
+		tests := []struct {
+			bounds         Bounds
+			keys, expected []IntervalKey[int, string]
+			affected       []string
+		}{
+			{
+				bounds:   Closed,
+				keys:     []IntervalKey[int, string]{NewIntervalKey(0, 4, "a"), NewIntervalKey(0, 20, "b"), NewIntervalKey(0, 30, "c")},
+				expected: []IntervalKey[int, string]{NewIntervalKey(0, 4, "a"), NewIntervalKey(11, 20, "b"), NewIntervalKey(11, 30, "c")},
+				affected: []string{"b", "c"},
+			},
+			{
+				bounds:   HalfOpen,
+				keys:     []IntervalKey[int, string]{NewIntervalKey(0, 5, "a"), NewIntervalKey(0, 20, "b"), NewIntervalKey(0, 30, "c")},
+				expected: []IntervalKey[int, string]{NewIntervalKey(0, 5, "a"), NewIntervalKey(10, 20, "b"), NewIntervalKey(10, 30, "c")},
+				affected: []string{"b", "c"},
+			},
+		}
+		for _, test := range tests {
+			list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithBounds(test.bounds))
+			for _, k := range test.keys {
+				list.Insert(k)
+			}
+			affected := list.Subtract(NewIntervalQuery[int, string](5, 10))
+			if got := keysOf(affected); !slices.Equal(got, test.affected) {
+				t.Errorf("%s: expected %v affected. got %v", test.bounds, test.affected, got)
+			}
+			if got := slices.Collect(list.All()); !slices.Equal(got, test.expected) {
+				t.Errorf("%s: expected %v. got %v", test.bounds, test.expected, got)
+			}
+		}
+	})
+}
+
+func TestInsertExclusive(t *testing.T) {
+	list := newTestList()
+	list.Insert(NewIntervalKey(0, 100, "outer"))
+	list.Insert(NewIntervalKey(200, 210, "a"))
+	list.Insert(NewIntervalKey(220, 230, "b"))
+	list.Insert(NewIntervalKey(240, 250, "c"))
+
+	tests := []struct {
+		interval  IntervalKey[int, string]
+		conflicts []string
+	}{
+		{interval: NewIntervalKey(50, 60, "nested"), conflicts: []string{"outer"}},
+		{interval: NewIntervalKey(205, 225, "spans"), conflicts: []string{"a", "b"}},
+		{interval: NewIntervalKey(150, 300, "covers"), conflicts: []string{"a", "b", "c"}},
+		{interval: NewIntervalKey(230, 235, "touches"), conflicts: []string{"b"}},
+		{interval: NewIntervalKey(211, 219, "fits"), conflicts: nil},
+	}
+	for _, test := range tests {
+		length := list.length
+		conflicts, err := list.InsertExclusive(test.interval)
+		if got := keysOf(conflicts); !slices.Equal(got, test.conflicts) {
+			t.Errorf("InsertExclusive(%s): expected conflicts %v. got %v", test.interval, test.conflicts, got)
+		}
+		if test.conflicts == nil {
+			if err != nil || list.length != length+1 {
+				t.Errorf("InsertExclusive(%s): expected insert. got %v", test.interval, err)
+			}
+			continue
+		}
+		var overlapErr *OverlapError[int, string]
+		if !errors.Is(err, ErrOverlap) || !errors.As(err, &overlapErr) || len(overlapErr.Conflicts) != len(test.conflicts) {
+			t.Errorf("InsertExclusive(%s): expected OverlapError. got %v", test.interval, err)
+		}
+		if list.length != length {
+			t.Errorf("InsertExclusive(%s): expected list to be unchanged", test.interval)
+		}
+	}
+
+	t.Run("Invalid interval", func(t *testing.T) {
+		list := newTestList()
+		if _, err := list.InsertExclusive(IntervalKey[int, string]{Start: 10, End: 0}); !errors.Is(err, ErrInvalidInterval) {
+			t.Errorf("expected %v. got %v", ErrInvalidInterval, err)
+		}
+		if list.length != 0 {
+			t.Errorf("expected list to be unchanged")
+		}
+	})
+
+	t.Run("Coalescing", func(t *testing.T) {
+		list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithBounds(HalfOpen), WithCoalesce(func(a, b string) string { return a + b }))
+		list.Insert(NewIntervalKey(0, 10, "a"))
+		if _, err := list.InsertExclusive(NewIntervalKey(10, 20, "b")); err != nil {
+			t.Fatalf("expected touching interval to be inserted. got %v", err)
+		}
+		if got, expected := slices.Collect(list.All()), []IntervalKey[int, string]{NewIntervalKey(0, 20, "ab")}; !slices.Equal(got, expected) {
+			t.Errorf("expected %v. got %v", expected, got)
+		}
+		if _, err := list.InsertExclusive(NewIntervalKey(15, 25, "c")); !errors.Is(err, ErrOverlap) {
+			t.Errorf("expected %v. got %v", ErrOverlap, err)
+		}
+	})
+}
+
+func TestTryInsert(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		interval IntervalKey[int, string]
+		err      error
+	}{
+		{name: "valid", interval: IntervalKey[int, string]{Start: -5, End: 10}},
+		{name: "empty closed", interval: IntervalKey[int, string]{Start: 5, End: 5}},
+		{name: "end before start", interval: IntervalKey[int, string]{Start: 10, End: 5}, err: ErrInvalidInterval},
+		{name: "empty half-open", opts: []Option{WithBounds(HalfOpen)}, interval: IntervalKey[int, string]{Start: 5, End: 5}, err: ErrInvalidInterval},
+		{name: "negative bound", opts: []Option{WithNonNegativeBounds()}, interval: IntervalKey[int, string]{Start: -5, End: 10}, err: ErrNegativeBound},
+		{name: "zero bound", opts: []Option{WithNonNegativeBounds()}, interval: IntervalKey[int, string]{Start: 0, End: 10}},
+	}
+	for _, test := range tests {
+		list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), test.opts...)
+		_, err := list.TryInsert(test.interval)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%s: expected %v. got %v", test.name, test.err, err)
+		}
+		if inserted := list.length == 1; inserted != (test.err == nil) {
+			t.Errorf("%s: expected inserted %t. got %t", test.name, test.err == nil, inserted)
+		}
+	}
+	t.Run("NaN bounds", func(t *testing.T) {
+		list := New(NewNodePool[float64, string](), rand.NewPCG(2, 3))
+		for _, ik := range []IntervalKey[float64, string]{{Start: math.NaN(), End: 1}, {Start: 0, End: math.NaN()}} {
+			if _, err := list.TryInsert(ik); !errors.Is(err, ErrInvalidInterval) {
+				t.Errorf("[%v,%v]: expected %v. got %v", ik.Start, ik.End, ErrInvalidInterval, err)
+			}
+		}
+		if _, err := list.TryInsert(IntervalKey[float64, string]{Start: 0, End: math.Inf(1)}); err != nil {
+			t.Errorf("expected infinite bound to be valid. got %v", err)
+		}
+		if list.length != 1 {
+			t.Errorf("expected 1 key. got %d", list.length)
+		}
+	})
+}
@@ -0,0 +1,93 @@
+package islist
+
+import "iter"
+
+// All returns an iterator over all keys in the list, in ascending order.
+// The list must not be modified during iteration.
+func (sl *SkipList[T, V]) All() iter.Seq[IntervalKey[T, V]] {
+	return func(yield func(IntervalKey[T, V]) bool) {
+		for n := sl.head.levels[0].next; n != nil; n = n.levels[0].next {
+			if !yield(n.intervalKey) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over all keys in the list, in descending order.
+// The list must not be modified during iteration.
+func (sl *SkipList[T, V]) Backward() iter.Seq[IntervalKey[T, V]] {
+	return func(yield func(IntervalKey[T, V]) bool) {
+		for n := sl.tail; n != nil; n = n.prev {
+			if !yield(n.intervalKey) {
+				return
+			}
+		}
+	}
+}
+
+// BackwardFrom returns an iterator over the keys whose interval starts at or before the point,
+// in descending order, starting from Floor(point).
+// The list must not be modified during iteration.
+func (sl *SkipList[T, V]) BackwardFrom(point T) iter.Seq[IntervalKey[T, V]] {
+	return func(yield func(IntervalKey[T, V]) bool) {
+		n, _ := sl.seek(func(ik IntervalKey[T, V]) bool { return sl.compare(ik.Start, point) <= 0 })
+		if n == sl.head {
+			return
+		}
+		for ; n != nil; n = n.prev {
+			if !yield(n.intervalKey) {
+				return
+			}
+		}
+	}
+}
+
+// OverlapsSeq returns an iterator over the keys that overlap the query interval, in list order.
+// Each key is paired with its 0-based index position in the list, see GetByIndex.
+// Unlike Overlaps, it does not allocate a result slice.
+// The list must not be modified during iteration.
+func (sl *SkipList[T, V]) OverlapsSeq(interval IntervalKey[T, V]) iter.Seq2[int, IntervalKey[T, V]] {
+	return func(yield func(int, IntervalKey[T, V]) bool) {
+		inclusive := sl.bounds == Closed
+		n, pos := sl.nextOverlap(sl.head, 0, interval.Start, inclusive)
+		for ; n != nil && sl.before(n.intervalKey.Start, interval.End, inclusive); n, pos = sl.nextOverlap(n, pos, interval.Start, inclusive) {
+			if !yield(pos-1, n.intervalKey) {
+				return
+			}
+		}
+	}
+}
+
+// Stab returns an iterator over the keys whose interval contains the point, in list order.
+// It is equivalent to OverlapsSeq with a query interval of [point, point], without building one.
+// The list must not be modified during iteration.
+func (sl *SkipList[T, V]) Stab(point T) iter.Seq[IntervalKey[T, V]] {
+	return func(yield func(IntervalKey[T, V]) bool) {
+		n, pos := sl.nextOverlap(sl.head, 0, point, sl.bounds.includesEnd())
+		for ; n != nil && sl.before(n.intervalKey.Start, point, sl.bounds.includesStart()); n, pos = sl.nextOverlap(n, pos, point, sl.bounds.includesEnd()) {
+			if !yield(n.intervalKey) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the keys whose Start is within [from, to), in ascending order.
+// The list must not be modified during iteration.
+func (sl *SkipList[T, V]) Range(from, to T) iter.Seq[IntervalKey[T, V]] {
+	return func(yield func(IntervalKey[T, V]) bool) {
+		// Find the last node with a Start before from.
+		n := sl.head
+		for i := sl.maxSearchLevel(); i >= 0; i-- {
+			for n.levels[i].next != nil && sl.compare(n.levels[i].next.intervalKey.Start, from) < 0 {
+				n = n.levels[i].next
+			}
+		}
+		for n = n.levels[0].next; n != nil && sl.compare(n.intervalKey.Start, to) < 0; n = n.levels[0].next {
+			if !yield(n.intervalKey) {
+				return
+			}
+		}
+	}
+}
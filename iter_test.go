@@ -0,0 +1,127 @@
+package islist
+
+import (
+	"slices"
+	"testing"
+)
+
+func keysOf(keys []IntervalKey[int, string]) []string {
+	r := make([]string, len(keys))
+	for i, k := range keys {
+		r[i] = k.Key
+	}
+	return r
+}
+
+func newIterTestList() *SkipList[int, string] {
+	list := newTestList()
+	list.Insert(NewIntervalKey(30, 40, "test-3"))
+	list.Insert(NewIntervalKey(5, 9, "test-1"))
+	list.Insert(NewIntervalKey(50, 60, "test-4"))
+	list.Insert(NewIntervalKey(10, 20, "test-2"))
+	list.Insert(NewIntervalKey(70, 80, "test-5"))
+	return list
+}
+
+func TestAll(t *testing.T) {
+	list := newIterTestList()
+	got := keysOf(slices.Collect(list.All()))
+	expected := []string{"test-1", "test-2", "test-3", "test-4", "test-5"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v. got %v", expected, got)
+	}
+}
+
+func TestBackward(t *testing.T) {
+	t.Run("Backward iteration", func(t *testing.T) {
+		list := newIterTestList()
+		got := keysOf(slices.Collect(list.Backward()))
+		expected := []string{"test-5", "test-4", "test-3", "test-2", "test-1"}
+		if !slices.Equal(got, expected) {
+			t.Errorf("expected %v. got %v", expected, got)
+		}
+	})
+
+	t.Run("Backward iteration after deleting first and last interval", func(t *testing.T) {
+		list := newIterTestList()
+		list.Delete(NewIntervalQuery[int, string](5, 9))
+		list.Delete(NewIntervalQuery[int, string](70, 80))
+		list.Delete(NewIntervalQuery[int, string](30, 40))
+		got := keysOf(slices.Collect(list.Backward()))
+		expected := []string{"test-4", "test-2"}
+		if !slices.Equal(got, expected) {
+			t.Errorf("expected %v. got %v", expected, got)
+		}
+	})
+
+	t.Run("Backward iteration of empty list", func(t *testing.T) {
+		list := newIterTestList()
+		for _, k := range slices.Collect(list.All()) {
+			list.Delete(k)
+		}
+		if got := slices.Collect(list.Backward()); len(got) != 0 {
+			t.Errorf("expected no keys. got %v", got)
+		}
+	})
+}
+
+func TestOverlapsSeq(t *testing.T) {
+	list := newIterTestList()
+
+	t.Run("Overlaps with index positions", func(t *testing.T) {
+		var indexes []int
+		var keys []string
+		for i, k := range list.OverlapsSeq(NewIntervalQuery[int, string](15, 55)) {
+			indexes = append(indexes, i)
+			keys = append(keys, k.Key)
+		}
+		if !slices.Equal(indexes, []int{1, 2, 3}) {
+			t.Errorf("expected indexes [1 2 3]. got %v", indexes)
+		}
+		if !slices.Equal(keys, []string{"test-2", "test-3", "test-4"}) {
+			t.Errorf("expected keys [test-2 test-3 test-4]. got %v", keys)
+		}
+		for _, i := range indexes {
+			if _, err := list.GetByIndex(i); err != nil {
+				t.Errorf("expected key at index %d. got %s", i, err)
+			}
+		}
+	})
+
+	t.Run("Overlaps stop early", func(t *testing.T) {
+		count := 0
+		for range list.OverlapsSeq(NewIntervalQuery[int, string](0, 100)) {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+		if count != 2 {
+			t.Errorf("expected 2 iterations. got %d", count)
+		}
+	})
+}
+
+func TestRange(t *testing.T) {
+	list := newIterTestList()
+	got := keysOf(slices.Collect(list.Range(10, 70)))
+	expected := []string{"test-2", "test-3", "test-4"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v. got %v", expected, got)
+	}
+	if got := slices.Collect(list.Range(81, 100)); len(got) != 0 {
+		t.Errorf("expected no keys. got %v", got)
+	}
+}
+
+func TestBackwardFrom(t *testing.T) {
+	list := newIterTestList()
+	got := keysOf(slices.Collect(list.BackwardFrom(55)))
+	expected := []string{"test-4", "test-3", "test-2", "test-1"}
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v. got %v", expected, got)
+	}
+	if got := slices.Collect(list.BackwardFrom(4)); len(got) != 0 {
+		t.Errorf("expected no keys. got %v", got)
+	}
+}
@@ -0,0 +1,93 @@
+package islist
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// LevelGenerator generates the levels of the nodes inserted in a list, see WithLevelGenerator.
+// A generator is used by a single list, under the same synchronization as the list.
+type LevelGenerator interface {
+	// Level returns the level of a new node, in [1, maxLevel].
+	Level(maxLevel int) int
+}
+
+// randomLevels generates levels with a geometric distribution.
+type randomLevels struct {
+	src       rand.Source
+	threshold int32 // A node is promoted to the next level if a random int32 is below it.
+}
+
+// NewRandomLevels returns a generator of random levels drawn from the source, where a node is
+// promoted to the next level with probability p, in (0, 1). This is the default generator of a
+// list, using the PCG passed to New and the WithProbability option.
+// Sources with the same seed generate the same levels.
+func NewRandomLevels(src rand.Source, p float64) LevelGenerator {
+	if !(p > 0 && p < 1) {
+		panic(fmt.Sprintf("invalid level probability: %v", p))
+	}
+	return &randomLevels{src: src, threshold: int32(p * math.MaxInt32)}
+}
+
+func (g *randomLevels) Level(maxLevel int) int {
+	level := 1
+	// Same draws as rand.Rand.Int32, without allocating a Rand.
+	for int32(g.src.Uint64()>>33) < g.threshold && level < maxLevel {
+		level++
+	}
+	return level
+}
+
+// fixedLevels generates levels from a fixed sequence.
+type fixedLevels struct {
+	levels []int
+	i      int
+}
+
+// NewFixedLevels returns a generator that cycles through the levels, each in [1, MaxLevel].
+// Levels above the maximum level of the list are lowered to it.
+// Lists built with the same sequence of levels and operations have the exact same structure.
+func NewFixedLevels(levels ...int) LevelGenerator {
+	if len(levels) == 0 {
+		panic("no levels")
+	}
+	for _, l := range levels {
+		if l < 1 || l > MaxLevel {
+			panic(fmt.Sprintf("invalid level: %d", l))
+		}
+	}
+	return &fixedLevels{levels: levels}
+}
+
+func (g *fixedLevels) Level(maxLevel int) int {
+	level := g.levels[g.i]
+	g.i = (g.i + 1) % len(g.levels)
+	return min(level, maxLevel)
+}
+
+// balancedLevels generates the levels of a perfectly balanced list.
+type balancedLevels struct {
+	base  int
+	count uint64 // Number of generated levels.
+}
+
+// NewBalancedLevels returns a generator where every base-th node is promoted to the next level,
+// with base >= 2. The k-th node is at level 1 + the number of times base divides k.
+// Nodes appended in ascending order, e.g. by NewFromSorted, form a perfectly balanced list
+// where each pointer at level i+1 skips base pointers at level i.
+func NewBalancedLevels(base int) LevelGenerator {
+	if base < 2 {
+		panic(fmt.Sprintf("invalid level base: %d", base))
+	}
+	return &balancedLevels{base: base}
+}
+
+func (g *balancedLevels) Level(maxLevel int) int {
+	g.count++
+	level := 1
+	for k := g.count; k%uint64(g.base) == 0 && level < maxLevel; k /= uint64(g.base) {
+		level++
+	}
+	return level
+}
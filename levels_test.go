@@ -0,0 +1,90 @@
+package islist
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestLevelGenerators(t *testing.T) {
+	levels := func(g LevelGenerator, n int) []int {
+		var ls []int
+		for i := 0; i < n; i++ {
+			ls = append(ls, g.Level(3))
+		}
+		return ls
+	}
+
+	t.Run("Fixed", func(t *testing.T) {
+		if got, expected := levels(NewFixedLevels(1, 4, 2), 5), []int{1, 3, 2, 1, 3}; !slices.Equal(got, expected) {
+			t.Errorf("expected levels %v. got %v", expected, got)
+		}
+		list := New[int, string](NewNodePool[int, string](), nil, WithLevelGenerator(NewFixedLevels(2, 1)))
+		for i := 0; i < 4; i++ {
+			list.Insert(NewIntervalKey(i, i+1, "key"))
+		}
+		assertListEqual(t, list, expectedList{level: 2, length: 4})
+		for i, n := 0, list.head.levels[0].next; n != nil; i, n = i+1, n.levels[0].next {
+			if expected := 2 - i%2; len(n.levels) != expected {
+				t.Errorf("expected node %d at level %d. got %d", i, expected, len(n.levels))
+			}
+		}
+	})
+
+	t.Run("Balanced", func(t *testing.T) {
+		if got, expected := levels(NewBalancedLevels(2), 8), []int{1, 2, 1, 3, 1, 2, 1, 3}; !slices.Equal(got, expected) {
+			t.Errorf("expected levels %v. got %v", expected, got)
+		}
+		keys := make([]IntervalKey[int, string], 64)
+		for i := range keys {
+			keys[i] = NewIntervalKey(i, i+1, "key")
+		}
+		list, err := NewFromSorted(NewNodePool[int, string](), nil, keys, WithLevelGenerator(NewBalancedLevels(4)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertListEqual(t, list, expectedList{level: 4, length: 64})
+		for i := 0; i < list.maxLevel; i++ {
+			for n := list.head; n.levels[i].next != nil; n = n.levels[i].next {
+				if expected := 1 << (2 * i); n.levels[i].span != expected {
+					t.Fatalf("expected span %d at level %d. got %d", expected, i, n.levels[i].span)
+				}
+			}
+		}
+		if err := list.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Random is reproducible", func(t *testing.T) {
+		print := func() string {
+			list := New(NewNodePool[int, string](), rand.NewPCG(7, 8))
+			for i := 0; i < 100; i++ {
+				list.Insert(NewIntervalKey(i, i+1, "key"))
+			}
+			var buf bytes.Buffer
+			list.Print(&buf, MaxLevel)
+			return buf.String()
+		}
+		if print() != print() {
+			t.Errorf("expected the same structure for the same seed")
+		}
+	})
+
+	t.Run("Random allocs", func(t *testing.T) {
+		g := NewRandomLevels(rand.NewPCG(2, 3), 0.25)
+		if allocs := testing.AllocsPerRun(100, func() { g.Level(MaxLevel) }); allocs != 0 {
+			t.Errorf("expected 0 allocs per level. got %v", allocs)
+		}
+	})
+
+	t.Run("Nil PCG", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("expected panic")
+			}
+		}()
+		New[int, string](NewNodePool[int, string](), nil)
+	})
+}
@@ -0,0 +1,197 @@
+package islist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+)
+
+const (
+	formatMagic   = "ISKL"
+	formatVersion = 1
+)
+
+var (
+	ErrInvalidData = errors.New("invalid skiplist data")
+	ErrChecksum    = errors.New("skiplist data checksum mismatch")
+)
+
+// nodeRecord represent a serialized node.
+type nodeRecord[T, V any] struct {
+	Level      uint8
+	Start, End T
+	Key        V
+}
+
+// WriteTo writes the list to w in a versioned binary format and returns the number of bytes written.
+//
+// The format is a header with a magic number and a version, followed by the length of the payload,
+// the payload and a CRC-32 checksum of the payload. The payload is a gob stream of the list length
+// followed by each node's level and key in ascending order, so T and V must be encodable by gob.
+// Storing the levels lets ReadFrom rebuild the exact same structure.
+func (sl *SkipList[T, V]) WriteTo(w io.Writer) (int64, error) {
+	var payload bytes.Buffer
+	enc := gob.NewEncoder(&payload)
+	if err := enc.Encode(sl.length); err != nil {
+		return 0, err
+	}
+	for n := sl.head.levels[0].next; n != nil; n = n.levels[0].next {
+		r := nodeRecord[T, V]{
+			Level: uint8(len(n.levels)),
+			Start: n.intervalKey.Start,
+			End:   n.intervalKey.End,
+			Key:   n.intervalKey.Key,
+		}
+		if err := enc.Encode(&r); err != nil {
+			return 0, err
+		}
+	}
+
+	header := make([]byte, 0, len(formatMagic)+1+binary.MaxVarintLen64)
+	header = append(header, formatMagic...)
+	header = append(header, formatVersion)
+	header = binary.AppendUvarint(header, uint64(payload.Len()))
+	trailer := binary.BigEndian.AppendUint32(nil, crc32.ChecksumIEEE(payload.Bytes()))
+
+	var written int64
+	for _, b := range [][]byte{header, payload.Bytes(), trailer} {
+		n, err := w.Write(b)
+		written += int64(n)
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+// ReadFrom replaces the contents of the list with a list read from r in the format written by WriteTo,
+// and returns the number of bytes read. The list must have been created with New or NewFunc.
+//
+// The list is rebuilt in O(n) from the stored levels, without searching the list or generating
+// random levels. The list is left unchanged if the data is invalid.
+func (sl *SkipList[T, V]) ReadFrom(r io.Reader) (int64, error) {
+	br := &countingReader{r: r}
+	read := func() int64 { return br.n }
+
+	header := make([]byte, len(formatMagic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return read(), err
+	}
+	if string(header[:len(formatMagic)]) != formatMagic {
+		return read(), ErrInvalidData
+	}
+	if v := header[len(formatMagic)]; v != formatVersion {
+		return read(), fmt.Errorf("%w: unsupported format version: %d", ErrInvalidData, v)
+	}
+	size, err := binary.ReadUvarint(br)
+	if err != nil {
+		return read(), err
+	}
+	if size > math.MaxInt64 {
+		return read(), fmt.Errorf("%w: payload length out of range: %d", ErrInvalidData, size)
+	}
+	// The length isn't covered by the checksum, so the payload grows with the data actually read
+	// rather than being allocated upfront.
+	var payload bytes.Buffer
+	if n, err := io.CopyN(&payload, br, int64(size)); err != nil {
+		if err == io.EOF {
+			return read(), fmt.Errorf("%w: payload of %d bytes shorter than its length %d", ErrInvalidData, n, size)
+		}
+		return read(), err
+	}
+	var checksum [4]byte
+	if _, err := io.ReadFull(br, checksum[:]); err != nil {
+		return read(), err
+	}
+	if binary.BigEndian.Uint32(checksum[:]) != crc32.ChecksumIEEE(payload.Bytes()) {
+		return read(), ErrChecksum
+	}
+	if err := sl.decode(payload.Bytes()); err != nil {
+		return read(), err
+	}
+	return read(), nil
+}
+
+// decode rebuilds the list from a payload written by WriteTo.
+func (sl *SkipList[T, V]) decode(payload []byte) error {
+	dec := gob.NewDecoder(bytes.NewReader(payload))
+	var length int
+	if err := dec.Decode(&length); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidData, err)
+	}
+	if length < 0 {
+		return fmt.Errorf("%w: negative length: %d", ErrInvalidData, length)
+	}
+	loaded := sl.newEmpty()
+	b := newBuilder(loaded)
+	for i := 0; i < length; i++ {
+		var r nodeRecord[T, V]
+		err := dec.Decode(&r)
+		if err == nil && (r.Level < 1 || r.Level > MaxLevel) {
+			err = fmt.Errorf("invalid level: %d", r.Level)
+		}
+		ik := IntervalKey[T, V]{Start: r.Start, End: r.End, Key: r.Key}
+		if err == nil && loaded.tail != nil && !less(sl.compare, loaded.tail.intervalKey, ik) {
+			err = fmt.Errorf("interval out of order: %s", ik)
+		}
+		if err != nil {
+			loaded.free()
+			return fmt.Errorf("%w: %w", ErrInvalidData, err)
+		}
+		// Levels above the maximum level of the list are dropped.
+		b.append(newNode(sl.pool, min(int(r.Level), sl.levelCap), ik))
+	}
+	if err := dec.Decode(&nodeRecord[T, V]{}); err != io.EOF {
+		loaded.free()
+		return fmt.Errorf("%w: more than %d records", ErrInvalidData, length)
+	}
+	b.finish()
+	sl.free()
+	*sl = *loaded
+	sl.check()
+	return nil
+}
+
+// MarshalBinary implements the encoding.BinaryMarshaler interface, see WriteTo.
+func (sl *SkipList[T, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if _, err := sl.WriteTo(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary implements the encoding.BinaryUnmarshaler interface, see ReadFrom.
+func (sl *SkipList[T, V]) UnmarshalBinary(data []byte) error {
+	n, err := sl.ReadFrom(bytes.NewReader(data))
+	if err == nil && n != int64(len(data)) {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidData, int64(len(data))-n)
+	}
+	return err
+}
+
+// countingReader counts the bytes read from a reader.
+// It reads single bytes without buffering, so no data past the list is consumed from the reader.
+type countingReader struct {
+	r   io.Reader
+	n   int64
+	buf [1]byte
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+func (cr *countingReader) ReadByte() (byte, error) {
+	if _, err := io.ReadFull(cr, cr.buf[:]); err != nil {
+		return 0, err
+	}
+	return cr.buf[0], nil
+}
@@ -0,0 +1,150 @@
+package islist
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"hash/crc32"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func printList(sl *SkipList[int, string]) string {
+	var b strings.Builder
+	sl.Print(&b, MaxLevel)
+	return b.String()
+}
+
+func TestMarshalBinary(t *testing.T) {
+	t.Run("Round trip restores the list structure", func(t *testing.T) {
+		list := newIterTestList()
+		list.Insert(NewIntervalKey(0, 1000, "outer"))
+		data, err := list.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		loaded := newTestList()
+		loaded.Insert(NewIntervalKey(1, 2, "replaced"))
+		if err := loaded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got, expected := printList(loaded), printList(list); got != expected {
+			t.Errorf("list structure mismatch. got\n%s\nexpected\n%s", got, expected)
+		}
+		assertListEqual(t, loaded, expectedList{level: list.maxLevel, length: list.length})
+		if !slices.Equal(slices.Collect(loaded.Backward()), slices.Collect(list.Backward())) {
+			t.Errorf("backward keys mismatch")
+		}
+		r := loaded.Overlaps(NewIntervalQuery[int, string](500, 510), QueryParam{})
+		if len(r) != 1 || r[0].Key != "outer" {
+			t.Errorf("expected [outer]. got %v", r)
+		}
+	})
+
+	t.Run("Round trip of empty list", func(t *testing.T) {
+		data, err := newTestList().MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		loaded := newIterTestList()
+		if err := loaded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		assertListEqual(t, loaded, expectedList{level: 1, length: 0})
+		loaded.Insert(NewIntervalKey(1, 2, "key"))
+		assertListEqual(t, loaded, expectedList{level: 1, length: 1})
+	})
+
+	t.Run("Loaded list supports updates", func(t *testing.T) {
+		data, _ := newIterTestList().MarshalBinary()
+		loaded := newTestList()
+		if err := loaded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		loaded.Insert(NewIntervalKey(45, 46, "test-6"))
+		loaded.Delete(NewIntervalQuery[int, string](10, 20))
+		expected := []string{"test-1", "test-3", "test-6", "test-4", "test-5"}
+		if got := keysOf(slices.Collect(loaded.All())); !slices.Equal(got, expected) {
+			t.Errorf("expected %v. got %v", expected, got)
+		}
+		for i, key := range expected {
+			if k, err := loaded.GetByIndex(i); err != nil || k.Key != key {
+				t.Errorf("expected %s at index %d. got %v", key, i, k)
+			}
+		}
+	})
+}
+
+func TestReadFrom(t *testing.T) {
+	t.Run("Read consecutive lists from a stream", func(t *testing.T) {
+		var buf bytes.Buffer
+		first := newIterTestList()
+		second := newTestList()
+		second.Insert(NewIntervalKey(1, 2, "key"))
+		n1, err1 := first.WriteTo(&buf)
+		n2, err2 := second.WriteTo(&buf)
+		if err1 != nil || err2 != nil {
+			t.Fatalf("unexpected errors: %v, %v", err1, err2)
+		}
+		if n1+n2 != int64(buf.Len()) {
+			t.Errorf("expected %d bytes written. got %d", buf.Len(), n1+n2)
+		}
+		a, b := newTestList(), newTestList()
+		if n, err := a.ReadFrom(&buf); err != nil || n != n1 {
+			t.Errorf("expected %d bytes read. got %d, %v", n1, n, err)
+		}
+		if n, err := b.ReadFrom(&buf); err != nil || n != n2 {
+			t.Errorf("expected %d bytes read. got %d, %v", n2, n, err)
+		}
+		assertListEqual(t, a, expectedList{level: first.maxLevel, length: 5})
+		assertListEqual(t, b, expectedList{level: second.maxLevel, length: 1})
+	})
+
+	t.Run("Corrupt data leaves list unchanged", func(t *testing.T) {
+		data, _ := newIterTestList().MarshalBinary()
+		tests := []struct {
+			name     string
+			data     []byte
+			expected error
+		}{
+			{"bad magic", append([]byte("XXXX"), data[4:]...), ErrInvalidData},
+			{"bad version", append(append([]byte{}, data[:4]...), append([]byte{99}, data[5:]...)...), ErrInvalidData},
+			{"bad checksum", append(append([]byte{}, data[:len(data)-1]...), data[len(data)-1]+1), ErrChecksum},
+			{"max length", binary.AppendUvarint(append([]byte{}, data[:5]...), math.MaxUint64), ErrInvalidData},
+			{"max int64 length", binary.AppendUvarint(append([]byte{}, data[:5]...), math.MaxInt64), ErrInvalidData},
+			{"truncated payload", data[:len(data)/2], ErrInvalidData},
+			{"negative length", frame(t, -3), ErrInvalidData},
+			{"missing records", frame(t, 2, nodeRecord[int, string]{Level: 1, Start: 0, End: 1}), ErrInvalidData},
+			{"extra records", frame(t, 1, nodeRecord[int, string]{Level: 1, Start: 0, End: 1}, nodeRecord[int, string]{Level: 1, Start: 2, End: 3}), ErrInvalidData},
+		}
+		for _, test := range tests {
+			list := newTestList()
+			list.Insert(NewIntervalKey(1, 2, "key"))
+			if err := list.UnmarshalBinary(test.data); !errors.Is(err, test.expected) {
+				t.Errorf("%s: expected error %v. got %v", test.name, test.expected, err)
+			}
+			assertListEqual(t, list, expectedList{level: 1, length: 1})
+		}
+	})
+}
+
+// frame returns the length and records in a payload with a valid header and checksum.
+func frame(t *testing.T, length int, records ...nodeRecord[int, string]) []byte {
+	var payload bytes.Buffer
+	enc := gob.NewEncoder(&payload)
+	if err := enc.Encode(length); err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		if err := enc.Encode(&r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data := append([]byte(formatMagic), formatVersion)
+	data = binary.AppendUvarint(data, uint64(payload.Len()))
+	data = append(data, payload.Bytes()...)
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(payload.Bytes()))
+}
@@ -1,32 +1,54 @@
 package islist
 
 // nodeLevel represent a node's level in a list.
-type nodeLevel struct {
-	next *Node
-	span int
+type nodeLevel[T, V any] struct {
+	next   *Node[T, V]
+	span   int
+	maxEnd T // Maximum End of the nodes in (n, next], valid only when next is non-nil.
 }
 
 // Node represents a node in a list.
-type Node struct {
-	intervalKey IntervalKey
-	levels      []nodeLevel
+type Node[T, V any] struct {
+	intervalKey IntervalKey[T, V]
+	levels      []nodeLevel[T, V]
+	prev        *Node[T, V] // Previous node at the base level, nil for the first node.
 }
 
-func (n *Node) String() string {
+func (n *Node[T, V]) String() string {
 	if n == nil {
 		return "nil"
 	}
 	return n.intervalKey.String()
 }
 
+// updateMaxEnd recomputes the maximum End covered by the node's forward pointer at the given level.
+// The pointers of the level below must be up to date.
+func (n *Node[T, V]) updateMaxEnd(level int, compare func(a, b T) int) {
+	next := n.levels[level].next
+	if next == nil {
+		return
+	}
+	if level == 0 {
+		n.levels[0].maxEnd = next.intervalKey.End
+		return
+	}
+	// The pointers at the level below partition (n, next] into consecutive runs.
+	m := n.levels[level-1].maxEnd
+	for x := n.levels[level-1].next; x != next; x = x.levels[level-1].next {
+		if compare(x.levels[level-1].maxEnd, m) > 0 {
+			m = x.levels[level-1].maxEnd
+		}
+	}
+	n.levels[level].maxEnd = m
+}
+
 // reset resets the node to its original state.
-func (n *Node) reset() *Node {
+func (n *Node[T, V]) reset() *Node[T, V] {
 	if n == nil {
 		return n
 	}
-	n.levels = n.levels[:0] // Reset without deallocating the slice.
-	n.intervalKey.Start = 0
-	n.intervalKey.End = 0
-	n.intervalKey.Key = ""
+	n.levels = n.levels[:0]             // Reset without deallocating the slice.
+	n.intervalKey = IntervalKey[T, V]{} // Drop any reference held by the key.
+	n.prev = nil
 	return n
 }
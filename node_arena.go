@@ -0,0 +1,70 @@
+package islist
+
+import "fmt"
+
+// DefaultSlabSize is the default number of nodes per slab of a NodeArena.
+const DefaultSlabSize = 4096
+
+// NodeArena represents an allocator that stores nodes and their levels in large contiguous slabs,
+// to use across lists with values of type V. Compared to a NodePool it cuts the number of heap
+// objects by about the slab size, which shortens GC scans of large lists, and keeps nodes
+// allocated together close in memory. A GC of a list of 10^6 nodes takes about a third of the
+// time with an arena, see BenchmarkNodeAllocators.
+//
+// Nodes still link to each other by pointer rather than by index into the slabs, so lists don't
+// depend on their allocator, and traversals don't go through it. The GC still follows the links,
+// but it marks a slab once, rather than each node and its levels.
+//
+// Released nodes are reused by later nodes with the same number of levels. The slabs themselves
+// are only freed once the arena and all lists using it are unreachable.
+// An arena is not safe for concurrent use, so it must not be shared by lists that are modified
+// concurrently.
+type NodeArena[T, V any] struct {
+	slabSize int
+	nodes    []Node[T, V]          // Unused nodes of the current node slab.
+	levels   []nodeLevel[T, V]     // Unused levels of the current level slab.
+	free     [MaxLevel]*Node[T, V] // Released nodes by number of levels, chained through prev.
+}
+
+// NewNodeArena returns a new arena that allocates slabs of slabSize nodes, or DefaultSlabSize
+// if slabSize is 0.
+func NewNodeArena[T, V any](slabSize int) *NodeArena[T, V] {
+	if slabSize == 0 {
+		slabSize = DefaultSlabSize
+	}
+	if slabSize < 0 {
+		panic(fmt.Sprintf("invalid slab size: %d", slabSize))
+	}
+	return &NodeArena[T, V]{slabSize: slabSize}
+}
+
+// get returns a released node with the same number of levels, or carves a new one from the slabs.
+func (a *NodeArena[T, V]) get(level int) *Node[T, V] {
+	if n := a.free[level-1]; n != nil {
+		a.free[level-1] = n.prev
+		n.prev = nil
+		n.levels = n.levels[:level]
+		return n
+	}
+	if len(a.nodes) == 0 {
+		a.nodes = make([]Node[T, V], a.slabSize)
+	}
+	n := &a.nodes[0]
+	a.nodes = a.nodes[1:]
+	if len(a.levels) < level {
+		// Any rest of the current slab is left unused.
+		a.levels = make([]nodeLevel[T, V], max(a.slabSize, level))
+	}
+	n.levels = a.levels[:level:level] // Cap the levels, so the level count is known on release.
+	a.levels = a.levels[level:]
+	return n
+}
+
+// put releases any resources associated with a node and keeps it for reuse.
+func (a *NodeArena[T, V]) put(n *Node[T, V]) {
+	level := cap(n.levels)
+	clear(n.levels) // Drop the references to other nodes.
+	n.reset()
+	n.prev = a.free[level-1]
+	a.free[level-1] = n
+}
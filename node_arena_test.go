@@ -0,0 +1,34 @@
+package islist
+
+import "testing"
+
+func TestNodeArena(t *testing.T) {
+	t.Run("Reuse by level", func(t *testing.T) {
+		a := NewNodeArena[int, string](0)
+		n1, n2 := a.get(1), a.get(3)
+		n2.levels[2].span = 5
+		a.put(n1)
+		a.put(n2)
+		if n := a.get(3); n != n2 || len(n.levels) != 3 || n.levels[2].span != 0 {
+			t.Errorf("expected the released node with 3 cleared levels")
+		}
+		if n := a.get(2); n == n1 || len(n.levels) != 2 {
+			t.Errorf("expected a new node with 2 levels")
+		}
+		if n := a.get(1); n != n1 {
+			t.Errorf("expected the released node with 1 level")
+		}
+	})
+
+	t.Run("Allocs", func(t *testing.T) {
+		a := NewNodeArena[int, string](64)
+		n := a.get(2)
+		a.put(n)
+		if allocs := testing.AllocsPerRun(100, func() { a.put(a.get(2)) }); allocs != 0 {
+			t.Errorf("expected no allocations. got %.1f", allocs)
+		}
+		if allocs := testing.AllocsPerRun(10, func() { a.get(1) }); allocs != 0 {
+			t.Errorf("expected no allocations within a slab. got %.1f", allocs)
+		}
+	})
+}
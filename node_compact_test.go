@@ -0,0 +1,20 @@
+package islist
+
+import "testing"
+
+func TestCompactNodePool(t *testing.T) {
+	t.Run("Inline levels", func(t *testing.T) {
+		p := NewCompactNodePool[int, string]()
+		for level, expected := range map[int]int{1: 1, 2: 2, 3: 4, 4: 4, 5: 5, MaxLevel: MaxLevel} {
+			n := p.get(level)
+			if len(n.levels) != level || cap(n.levels) != expected {
+				t.Errorf("expected %d levels with capacity %d. got %d, %d", level, expected, len(n.levels), cap(n.levels))
+			}
+			n.levels[level-1].span = 1
+			p.put(n)
+			if n := p.get(level); n.levels[level-1].span != 0 {
+				t.Errorf("expected cleared levels for level %d", level)
+			}
+		}
+	})
+}
@@ -2,28 +2,44 @@ package islist
 
 import "sync"
 
-// NodePool represents a pool of reusable node objects to use across lists.
+// NodeAllocator allocates the nodes of lists, see NodePool and NodeArena.
+type NodeAllocator[T, V any] interface {
+	// get returns a node with the given number of cleared levels.
+	get(level int) *Node[T, V]
+	// put releases the node for reuse.
+	put(n *Node[T, V])
+}
+
+// NodePool represents a pool of reusable node objects to use across lists with values of type V.
 // A Pool is safe for concurrent use by multiple goroutines.
-type NodePool struct {
+type NodePool[T, V any] struct {
 	pool sync.Pool
 }
 
-func NewNodePool() *NodePool {
-	return &NodePool{
+func NewNodePool[T, V any]() *NodePool[T, V] {
+	return &NodePool[T, V]{
 		pool: sync.Pool{
 			New: func() any {
-				return &Node{}
+				return &Node[T, V]{}
 			},
 		},
 	}
 }
 
 // get retrieves a node from the pool or creates a new one.
-func (p *NodePool) get() *Node {
-	return p.pool.Get().(*Node)
+func (p *NodePool[T, V]) get(level int) *Node[T, V] {
+	n := p.pool.Get().(*Node[T, V])
+	if cap(n.levels) >= level {
+		// Reuse any preallocated capacity.
+		n.levels = n.levels[:level]
+		clear(n.levels)
+	} else {
+		n.levels = make([]nodeLevel[T, V], level)
+	}
+	return n
 }
 
 // put releases any resources associated with a node and returns it to the pool for reuse.
-func (p *NodePool) put(n *Node) {
+func (p *NodePool[T, V]) put(n *Node[T, V]) {
 	p.pool.Put(n.reset())
 }
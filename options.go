@@ -0,0 +1,138 @@
+package islist
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+)
+
+// Option configures a list, see New.
+type Option func(*options)
+
+// options represent the configuration of a list.
+type options struct {
+	merge       any // func(a, b V) V, see WithCoalesce.
+	bounds      Bounds
+	nonNegative bool
+	debug       func(error)
+	probability float64
+	maxLevel    int
+	searchCap   int
+	capSearch   bool // Whether searchCap was set, see WithSearchLevelCap.
+	levels      LevelGenerator
+}
+
+// WithProbability sets the probability p, in (0, 1), that a node is promoted to the next level.
+// The default is Probability. A higher p makes searches shorter at the cost of more levels per node.
+// With a custom level generator, p only sets the number of levels expected for a list length.
+func WithProbability(p float64) Option {
+	return func(o *options) {
+		o.probability = p
+	}
+}
+
+// WithMaxLevel sets the maximum level of a node, in [1, MaxLevel]. The default is MaxLevel.
+// A list holds at most about (1/p)^level elements efficiently, and its head allocates a level
+// for each, so small lists can use a lower maximum.
+func WithMaxLevel(level int) Option {
+	return func(o *options) {
+		o.maxLevel = level
+	}
+}
+
+// WithSearchLevelCap sets the maximum number of levels, in [1, MaxLevel], searched by Get,
+// GetByIndex, Floor and the other lookups that descend the list. By default the cap adapts to the
+// list length, one level above the log_(1/p)(n) levels expected for n elements.
+// Inserts and deletes always search every level.
+func WithSearchLevelCap(levels int) Option {
+	return func(o *options) {
+		o.searchCap = levels
+		o.capSearch = true
+	}
+}
+
+// WithLevelGenerator sets the generator of the levels of new nodes, e.g. NewFixedLevels or
+// NewBalancedLevels for lists with a reproducible structure. The default is NewRandomLevels with
+// the PCG passed to New. The generator must not be shared with other lists.
+func WithLevelGenerator(g LevelGenerator) Option {
+	return func(o *options) {
+		o.levels = g
+	}
+}
+
+// WithBounds sets whether the bounds of the intervals are included in the intervals.
+// The default is Closed. The bounds apply to overlap and point queries, exclusive inserts,
+// coalescing, subtraction, gaps and free slots.
+// TryInsert rejects empty intervals when the bounds are not Closed.
+func WithBounds(b Bounds) Option {
+	return func(o *options) {
+		o.bounds = b
+	}
+}
+
+// WithNonNegativeBounds makes TryInsert reject intervals with a bound that is less than the
+// zero value of the bound type.
+func WithNonNegativeBounds() Option {
+	return func(o *options) {
+		o.nonNegative = true
+	}
+}
+
+// WithDebugHook makes the list check its invariants with Validate after every modification, and
+// call hook with any violation found. Validation is O(n) per modification, so the hook is meant
+// for tests and debugging only.
+func WithDebugHook(hook func(err error)) Option {
+	return func(o *options) {
+		o.debug = hook
+	}
+}
+
+// WithCoalesce enables coalescing mode, in which Insert merges a new interval with all intervals
+// it overlaps or touches into a single interval that spans them.
+// The key of the merged interval is folded from the keys of the existing intervals, in list order,
+// followed by the new key: merge(merge(k1, k2), new). The type V must match the list value type.
+func WithCoalesce[V any](merge func(a, b V) V) Option {
+	return func(o *options) {
+		o.merge = merge
+	}
+}
+
+// apply applies the options to the list, drawing levels from the PCG by default.
+func (sl *SkipList[T, V]) apply(PCG *rand.PCG, opts []Option) {
+	o := options{probability: float64(Probability), maxLevel: MaxLevel}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.merge != nil {
+		merge, ok := o.merge.(func(a, b V) V)
+		if !ok {
+			panic(fmt.Sprintf("coalesce merge function %T does not match list value type", o.merge))
+		}
+		sl.merge = merge
+	}
+	if o.bounds > LeftOpen {
+		panic(fmt.Sprintf("invalid interval bounds: %s", o.bounds))
+	}
+	sl.bounds = o.bounds
+	sl.nonNegative = o.nonNegative
+	sl.debug = o.debug
+	if !(o.probability > 0 && o.probability < 1) {
+		panic(fmt.Sprintf("invalid level probability: %v", o.probability))
+	}
+	if o.maxLevel < 1 || o.maxLevel > MaxLevel {
+		panic(fmt.Sprintf("invalid max level: %d", o.maxLevel))
+	}
+	if o.capSearch && (o.searchCap < 1 || o.searchCap > MaxLevel) {
+		panic(fmt.Sprintf("invalid search level cap: %d", o.searchCap))
+	}
+	if o.levels == nil {
+		if PCG == nil {
+			panic("nil PCG without a level generator")
+		}
+		o.levels = NewRandomLevels(PCG, o.probability)
+	}
+	sl.levels = o.levels
+	sl.levelCap = o.maxLevel
+	sl.searchCap = o.searchCap
+	sl.levelScale = 1 / math.Log2(1/o.probability)
+}
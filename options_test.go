@@ -0,0 +1,91 @@
+package islist
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestLevelOptions(t *testing.T) {
+	newList := func(opts ...Option) *SkipList[int, string] {
+		list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), opts...)
+		for i := 0; i < 5000; i++ {
+			list.Insert(NewIntervalKey(i, i+1, "key"))
+		}
+		return list
+	}
+
+	t.Run("Max level", func(t *testing.T) {
+		list := newList(WithMaxLevel(3))
+		if len(list.head.levels) != 3 {
+			t.Errorf("expected 3 head levels. got %d", len(list.head.levels))
+		}
+		assertListEqual(t, list, expectedList{level: 3, length: 5000})
+		assertIndexable(t, list)
+	})
+
+	t.Run("Probability", func(t *testing.T) {
+		if low, high := newList(WithProbability(0.1)), newList(WithProbability(0.5)); low.maxLevel >= high.maxLevel {
+			t.Errorf("expected fewer levels for p=0.1. got %d, p=0.5 got %d", low.maxLevel, high.maxLevel)
+		}
+		assertIndexable(t, newList(WithProbability(0.5)))
+	})
+
+	t.Run("Search level cap", func(t *testing.T) {
+		list := newList(WithSearchLevelCap(1))
+		if got := list.maxSearchLevel(); got != 0 {
+			t.Errorf("expected search from level 0. got %d", got)
+		}
+		if k := list.Get(NewIntervalQuery[int, string](4000, 4001)); k == nil {
+			t.Errorf("expected [4000,4001] found")
+		}
+		if k, err := list.GetByIndex(4000); err != nil || k.Start != 4000 {
+			t.Errorf("expected [4000,4001] at index 4000. got %v, %v", k, err)
+		}
+	})
+
+	t.Run("Adaptive search level", func(t *testing.T) {
+		list := newList()
+		if got, expected := list.maxSearchLevel(), list.maxLevel-1; got < expected-1 {
+			t.Errorf("expected search from level %d or above. got %d", expected-1, got)
+		}
+		// The number of searched levels grows with log_(4)(n).
+		for length, expected := range map[int]int{0: 1, 1: 2, 1_000_000: 11, 10_000_000: 13, 100_000_000: 15} {
+			list.length = length
+			if got := list.searchLevels(); got != expected {
+				t.Errorf("expected %d search levels for length %d. got %d", expected, length, got)
+			}
+		}
+	})
+
+	t.Run("Load into lower max level", func(t *testing.T) {
+		data, err := newList().MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithMaxLevel(2))
+		if err := list.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		assertListEqual(t, list, expectedList{level: 2, length: 5000})
+		assertIndexable(t, list)
+	})
+
+	invalid := map[string]Option{
+		"probability 0":   WithProbability(0),
+		"probability 1":   WithProbability(1),
+		"max level 0":     WithMaxLevel(0),
+		"max level 33":    WithMaxLevel(MaxLevel + 1),
+		"search level 0":  WithSearchLevelCap(0),
+		"search level 33": WithSearchLevelCap(MaxLevel + 1),
+	}
+	for name, opt := range invalid {
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Errorf("%s: expected panic", name)
+				}
+			}()
+			New(NewNodePool[int, string](), rand.NewPCG(2, 3), opt)
+		}()
+	}
+}
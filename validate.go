@@ -0,0 +1,108 @@
+package islist
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvariant is returned, wrapped, by Validate when the structure of a list is broken.
+var ErrInvariant = errors.New("list invariant violated")
+
+// Validate checks the structural invariants of the list and returns an error wrapping
+// ErrInvariant for the first violation found, or nil if the list is well-formed.
+//
+// It checks that nodes are in strictly ascending order, that the length, tail and backward
+// pointers match the base level, that maxLevel is the highest level in use, and that the span
+// and max End of every forward pointer are correct. Validate runs in O(n * maxLevel).
+func (sl *SkipList[T, V]) Validate() error {
+	if sl.head == nil {
+		return fmt.Errorf("%w: nil head", ErrInvariant)
+	}
+	if sl.maxLevel < 1 || sl.maxLevel > len(sl.head.levels) {
+		return fmt.Errorf("%w: maxLevel %d out of range [1,%d]", ErrInvariant, sl.maxLevel, len(sl.head.levels))
+	}
+	for i := sl.maxLevel; i < len(sl.head.levels); i++ {
+		if sl.head.levels[i].next != nil {
+			return fmt.Errorf("%w: head links level %d above maxLevel %d", ErrInvariant, i+1, sl.maxLevel)
+		}
+	}
+	if sl.maxLevel > 1 && sl.head.levels[sl.maxLevel-1].next == nil {
+		return fmt.Errorf("%w: maxLevel %d has no nodes", ErrInvariant, sl.maxLevel)
+	}
+
+	// Base level: order, levels, backward pointers, length and tail.
+	var prev *Node[T, V]
+	pos := 0
+	for n := sl.head.levels[0].next; n != nil; n = n.levels[0].next {
+		pos++
+		if len(n.levels) < 1 || len(n.levels) > sl.maxLevel {
+			return fmt.Errorf("%w: node %s at position %d has %d levels, maxLevel %d", ErrInvariant, n, pos, len(n.levels), sl.maxLevel)
+		}
+		if n.prev != prev {
+			return fmt.Errorf("%w: node %s at position %d has prev %s, expected %s", ErrInvariant, n, pos, n.prev, prev)
+		}
+		if prev != nil && !less(sl.compare, prev.intervalKey, n.intervalKey) {
+			return fmt.Errorf("%w: node %s at position %d is not after %s", ErrInvariant, n, pos, prev)
+		}
+		prev = n
+	}
+	if pos != sl.length {
+		return fmt.Errorf("%w: length %d, counted %d nodes", ErrInvariant, sl.length, pos)
+	}
+	if sl.tail != prev {
+		return fmt.Errorf("%w: tail %s, expected %s", ErrInvariant, sl.tail, prev)
+	}
+
+	// Each level: the forward pointers must reach every node of the level, in base order,
+	// with spans and max Ends that match the run of base nodes they skip.
+	for i := 0; i < sl.maxLevel; i++ {
+		x, xPos := sl.head, 0
+		var maxEnd T
+		pos := 0
+		for n := sl.head.levels[0].next; n != nil; n = n.levels[0].next {
+			pos++
+			if pos == xPos+1 || sl.compare(n.intervalKey.End, maxEnd) > 0 {
+				maxEnd = n.intervalKey.End
+			}
+			if len(n.levels) <= i {
+				continue
+			}
+			if err := sl.validatePointer(x, xPos, i, n, pos, maxEnd); err != nil {
+				return err
+			}
+			x, xPos = n, pos
+		}
+		if err := sl.validatePointer(x, xPos, i, nil, sl.length, maxEnd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validatePointer checks the forward pointer of node x, at position xPos, at the given level.
+// It must link to next at position pos, whose run of nodes (x, next] has the max End.
+func (sl *SkipList[T, V]) validatePointer(x *Node[T, V], xPos, level int, next *Node[T, V], pos int, maxEnd T) error {
+	l := x.levels[level]
+	if l.next != next {
+		return fmt.Errorf("%w: level %d: node %s links to %s, expected %s", ErrInvariant, level+1, x, l.next, next)
+	}
+	if l.span != pos-xPos {
+		return fmt.Errorf("%w: level %d: node %s has span %d, expected %d", ErrInvariant, level+1, x, l.span, pos-xPos)
+	}
+	if next != nil && sl.compare(l.maxEnd, maxEnd) != 0 {
+		return fmt.Errorf("%w: level %d: node %s has max End %v, expected %v", ErrInvariant, level+1, x, l.maxEnd, maxEnd)
+	}
+	return nil
+}
+
+// check records a modification of the list, and calls the debug hook, if any, with the error
+// returned by Validate. It is called after every modification.
+func (sl *SkipList[T, V]) check() {
+	sl.mods++
+	if sl.debug == nil {
+		return
+	}
+	if err := sl.Validate(); err != nil {
+		sl.debug(err)
+	}
+}
@@ -0,0 +1,86 @@
+package islist
+
+import (
+	"errors"
+	"math/rand/v2"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	t.Run("Valid after random operations", func(t *testing.T) {
+		list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithDebugHook(func(err error) {
+			t.Fatalf("unexpected invariant violation: %v", err)
+		}))
+		r := rand.New(rand.NewPCG(4, 5))
+		for i := 0; i < 2000; i++ {
+			start := r.IntN(1000)
+			ik := NewIntervalKey(start, start+r.IntN(50), "key")
+			switch r.IntN(4) {
+			case 0, 1:
+				list.Insert(ik)
+			case 2:
+				list.Delete(ik)
+			case 3:
+				list.DeleteOverlapping(NewIntervalQuery[int, string](start, start+5))
+			}
+		}
+		if err := list.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("Delete all nodes resets maxLevel", func(t *testing.T) {
+		list := newTestList()
+		for i := 0; i < 100; i++ {
+			list.Insert(NewIntervalKey(i, i+1, "key"))
+		}
+		if list.maxLevel < 2 {
+			t.Fatalf("expected a multi-level list. got level %d", list.maxLevel)
+		}
+		for i := 99; i >= 0; i-- {
+			list.Delete(NewIntervalKey(i, i+1, ""))
+		}
+		assertListEqual(t, list, expectedList{level: 1, length: 0})
+		if err := list.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		list.Insert(NewIntervalKey(5, 10, "key"))
+		if k := list.Get(NewIntervalQuery[int, string](5, 10)); k == nil {
+			t.Errorf("expected [5,10] after re-insert")
+		}
+	})
+
+	tests := []struct {
+		name    string
+		corrupt func(sl *SkipList[int, string])
+	}{
+		{name: "span", corrupt: func(sl *SkipList[int, string]) { sl.head.levels[0].span++ }},
+		{name: "length", corrupt: func(sl *SkipList[int, string]) { sl.length-- }},
+		{name: "maxLevel", corrupt: func(sl *SkipList[int, string]) { sl.maxLevel = 0 }},
+		{name: "order", corrupt: func(sl *SkipList[int, string]) { sl.tail.intervalKey.Start = -1 }},
+		{name: "prev", corrupt: func(sl *SkipList[int, string]) { sl.tail.prev = nil }},
+		{name: "tail", corrupt: func(sl *SkipList[int, string]) { sl.tail = sl.tail.prev }},
+		{name: "max End", corrupt: func(sl *SkipList[int, string]) { sl.head.levels[0].maxEnd = 1000 }},
+	}
+	for _, test := range tests {
+		list := newTestList()
+		for i := 0; i < 50; i++ {
+			list.Insert(NewIntervalKey(i*10, i*10+5, "key"))
+		}
+		test.corrupt(list)
+		if err := list.Validate(); !errors.Is(err, ErrInvariant) {
+			t.Errorf("%s: expected ErrInvariant. got %v", test.name, err)
+		}
+	}
+
+	t.Run("Debug hook reports violations", func(t *testing.T) {
+		var reported error
+		list := New(NewNodePool[int, string](), rand.NewPCG(2, 3), WithDebugHook(func(err error) { reported = err }))
+		list.Insert(NewIntervalKey(0, 10, "a"))
+		list.length++
+		list.Insert(NewIntervalKey(20, 30, "b"))
+		if !errors.Is(reported, ErrInvariant) {
+			t.Errorf("expected ErrInvariant reported. got %v", reported)
+		}
+	})
+}